@@ -0,0 +1,116 @@
+package pathtype
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// ErrNotAbs is the error, wrapped in an *os.PathError, returned by AsAbs
+// for a relative path.
+var ErrNotAbs = errors.New("path is not absolute")
+
+// ErrNotRel is the error, wrapped in an *os.PathError, returned by AsRel
+// for an absolute or rooted path.
+var ErrNotRel = errors.New("path is not relative")
+
+// anyPath is embedded by AbsPath and RelPath so that they have all the
+// methods of Path, while only the constructors can set it.
+type anyPath = Path
+
+// AbsPath is a Path known to be absolute. It has all the methods of Path,
+// and typed variants of those that keep a path absolute. An AbsPath can
+// only be made by ToAbs, AsAbs, Join and Dir, so the zero value is the
+// only AbsPath that is not absolute.
+type AbsPath struct {
+	anyPath
+}
+
+// RelPath is a Path known to be relative: neither absolute nor rooted. It
+// has all the methods of Path. A RelPath can only be made by AsRel, Join
+// and AbsPath.Rel, so that it can be joined to an AbsPath safely.
+type RelPath struct {
+	anyPath
+}
+
+// ToAbs returns path as an AbsPath, joining it to the current working
+// directory if it is relative, as Abs does.
+func (path Path) ToAbs() (AbsPath, error) {
+	p, err := path.Abs()
+	if err != nil {
+		return AbsPath{}, err
+	}
+	return AbsPath{p}, nil
+}
+
+// AsAbs returns path as an AbsPath, cleaned. If path is not absolute, it
+// returns ErrNotAbs rather than looking at the working directory.
+func (path Path) AsAbs() (AbsPath, error) {
+	if !path.IsAbs() {
+		return AbsPath{}, &fs.PathError{Op: "asabs", Path: string(path), Err: ErrNotAbs}
+	}
+	return AbsPath{path.Clean()}, nil
+}
+
+// AsRel returns path as a RelPath, cleaned. If path is absolute or rooted,
+// such as `\dir` on Windows, it returns ErrNotRel.
+func (path Path) AsRel() (RelPath, error) {
+	if path.IsAbs() || path.VolumeName() != "" || (len(path) > 0 && os.IsPathSeparator(path[0])) {
+		return RelPath{}, &fs.PathError{Op: "asrel", Path: string(path), Err: ErrNotRel}
+	}
+	return RelPath{path.Clean()}, nil
+}
+
+// Path returns p as a plain Path.
+func (p AbsPath) Path() Path {
+	return p.anyPath
+}
+
+// String returns p as a string.
+func (p AbsPath) String() string {
+	return string(p.anyPath)
+}
+
+// Join joins the relative paths elem to p.
+func (p AbsPath) Join(elem ...RelPath) AbsPath {
+	return AbsPath{p.anyPath.Join(relPaths(elem)...)}
+}
+
+// Dir returns all but the last element of p. The result is absolute.
+func (p AbsPath) Dir() AbsPath {
+	return AbsPath{p.anyPath.Dir()}
+}
+
+// Rel returns the relative path that leads from p to target, so that
+// p.Join(rel) is target cleaned. It fails if target cannot be made
+// relative to p, as on Windows when they are on different volumes.
+func (p AbsPath) Rel(target AbsPath) (RelPath, error) {
+	rel, err := p.anyPath.Rel(target.anyPath)
+	if err != nil {
+		return RelPath{}, err
+	}
+	return RelPath{rel}, nil
+}
+
+// Path returns p as a plain Path.
+func (p RelPath) Path() Path {
+	return p.anyPath
+}
+
+// String returns p as a string.
+func (p RelPath) String() string {
+	return string(p.anyPath)
+}
+
+// Join joins the relative paths elem to p. The result is relative.
+func (p RelPath) Join(elem ...RelPath) RelPath {
+	return RelPath{p.anyPath.Join(relPaths(elem)...)}
+}
+
+func relPaths(elem []RelPath) []Path {
+	res := make([]Path, len(elem))
+	for i, e := range elem {
+		res[i] = e.anyPath
+	}
+	return res
+}
@@ -0,0 +1,114 @@
+package pathtype_test
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+// absRoot returns the slash-separated path s below a root directory of the
+// OS, as a Path.
+func absRoot(s string) path {
+	if runtime.GOOS == "windows" {
+		return path(filepath.FromSlash(`C:/` + s))
+	}
+	return path("/" + s)
+}
+
+func TestAsAbs(t *testing.T) {
+	tests := []struct {
+		path   path
+		expect string
+		err    error
+	}{
+		{absRoot("usr/./local/"), string(absRoot("usr/local")), nil},
+		{absRoot(""), string(absRoot("")), nil},
+		{"usr", "", pt.ErrNotAbs},
+		{"", "", pt.ErrNotAbs},
+	}
+	for _, test := range tests {
+		t.Run(string(test.path), func(t *testing.T) {
+			p, err := test.path.AsAbs()
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect, true)
+			t1.Result(p.String(), errors.Is(err, test.err))
+			t1.AssertEquals()
+		})
+	}
+}
+
+func TestAsRel(t *testing.T) {
+	tests := []struct {
+		path   path
+		expect string
+		err    error
+	}{
+		{"bin/../sbin", "sbin", nil},
+		{".", ".", nil},
+		{"", ".", nil},
+		{absRoot("usr"), "", pt.ErrNotRel},
+	}
+	for _, test := range tests {
+		t.Run(string(test.path), func(t *testing.T) {
+			p, err := test.path.AsRel()
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect, true)
+			t1.Result(p.String(), errors.Is(err, test.err))
+			t1.AssertEquals()
+		})
+	}
+}
+
+func TestAbsPath(t *testing.T) {
+	wd, err := pt.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	toAbs, err := path("x/y").ToAbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	abs, err := absRoot("usr/local").AsAbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := absRoot("usr/share/doc").AsAbs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, err := path("sbin").AsRel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := abs.Rel(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc   string
+		result interface{}
+		expect interface{}
+	}{
+		{"ToAbs", toAbs.Path(), string(wd.Join("x/y"))},
+		{"Join", abs.Join(bin).String(), string(absRoot("usr/local/sbin"))},
+		{"Join twice", abs.Join(bin, bin).String(), string(absRoot("usr/local/sbin/sbin"))},
+		{"Dir", abs.Dir().String(), string(absRoot("usr"))},
+		{"Rel", rel.String(), filepath.FromSlash("../share/doc")},
+		{"Join Rel", abs.Join(rel).String(), string(absRoot("usr/share/doc"))},
+		{"RelPath Join", rel.Join(bin).String(), filepath.FromSlash("../share/doc/sbin")},
+		{"Base", abs.Base(), "local"},
+		{"Path", abs.Path(), string(absRoot("usr/local"))},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect)
+			t1.Result(test.result)
+			t1.AssertEquals()
+		})
+	}
+}
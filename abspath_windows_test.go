@@ -0,0 +1,54 @@
+package pathtype_test
+
+import (
+	"errors"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func TestAsRelWindows(t *testing.T) {
+	tests := []struct {
+		path   path
+		expect string
+		err    error
+	}{
+		{`a/b`, `a\b`, nil},
+		{`\dir`, "", pt.ErrNotRel},
+		{`/dir`, "", pt.ErrNotRel},
+		{`C:dir`, "", pt.ErrNotRel},
+		{`C:\dir`, "", pt.ErrNotRel},
+		{`\\host\share\dir`, "", pt.ErrNotRel},
+	}
+	for _, test := range tests {
+		t.Run(string(test.path), func(t *testing.T) {
+			p, err := test.path.AsRel()
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect, true)
+			t1.Result(p.String(), errors.Is(err, test.err))
+			t1.AssertEquals()
+		})
+	}
+}
+
+func TestAsAbsWindows(t *testing.T) {
+	tests := []struct {
+		path   path
+		expect string
+		err    error
+	}{
+		{`C:/dir/..`, `C:\`, nil},
+		{`\\host\share\dir`, `\\host\share\dir`, nil},
+		{`\dir`, "", pt.ErrNotAbs},
+		{`C:dir`, "", pt.ErrNotAbs},
+	}
+	for _, test := range tests {
+		t.Run(string(test.path), func(t *testing.T) {
+			p, err := test.path.AsAbs()
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect, true)
+			t1.Result(p.String(), errors.Is(err, test.err))
+			t1.AssertEquals()
+		})
+	}
+}
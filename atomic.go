@@ -0,0 +1,226 @@
+package pathtype
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AtomicOptions configures CreateAtomicWith.
+type AtomicOptions struct {
+	// Perm is the permission bits of the new file. As for WriteFile, they
+	// are modified by the umask.
+	Perm fs.FileMode
+
+	// KeepMode gives the new file the exact permission bits of the file it
+	// replaces, if there is one, instead of Perm.
+	KeepMode bool
+
+	// KeepOwner gives the new file the owner and group of the file it
+	// replaces, if there is one. It usually requires special privileges,
+	// and does nothing on Windows and Plan 9.
+	KeepOwner bool
+}
+
+// AtomicWriter is an io.WriteCloser that replaces a file atomically: the
+// data is written to a temporary file in the same directory, which takes
+// the place of the file only when Close succeeds. Readers of the file see
+// either its old contents or the complete new contents, even if the
+// program or the system crashes in between.
+type AtomicWriter struct {
+	path Path
+	f    *os.File
+	err  error
+	done bool
+}
+
+// errAtomicDone is returned by the methods of an AtomicWriter that was
+// already closed or aborted.
+var errAtomicDone = errors.New("atomic write already closed")
+
+// DirSyncError is returned by AtomicWriter.Close when the file was
+// replaced but its directory could not be flushed to stable storage
+// afterwards: the new contents are in place, but a crash may still bring
+// back the old ones.
+type DirSyncError struct {
+	Path Path  // the file that was replaced
+	Err  error // the error from flushing its directory
+}
+
+func (e *DirSyncError) Error() string {
+	return "replaced " + string(e.Path) + " but could not flush its directory: " + e.Err.Error()
+}
+
+func (e *DirSyncError) Unwrap() error {
+	return e.Err
+}
+
+// CreateAtomic returns an AtomicWriter that replaces the file at path when
+// it is closed. The new file keeps the permission bits of the file it
+// replaces; if there is none, it is created with mode 0644.
+func (path Path) CreateAtomic() (*AtomicWriter, error) {
+	return path.CreateAtomicWith(AtomicOptions{Perm: 0644, KeepMode: true})
+}
+
+// CreateAtomicWith is like CreateAtomic but creates the new file as
+// configured by opts. If path is a symbolic link, the link itself is
+// replaced, and KeepMode and KeepOwner refer to the file it points to.
+func (path Path) CreateAtomicWith(opts AtomicOptions) (*AtomicWriter, error) {
+	perm := opts.Perm
+	var uid, gid int
+	var chown, chmod bool
+	if opts.KeepMode || opts.KeepOwner {
+		info, err := path.Stat()
+		switch {
+		case err == nil:
+			if opts.KeepMode {
+				perm = info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)
+				chmod = true
+			}
+			if opts.KeepOwner {
+				uid, gid, chown = fileOwner(info)
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	// A new file is created with Perm minus the umask, like WriteFile does;
+	// a kept mode is set exactly once the file exists.
+	createPerm := perm
+	if chmod {
+		createPerm = 0600
+	}
+	f, err := createTemp(path.Dir(), "."+string(path.Base())+".tmp*", createPerm)
+	if err != nil {
+		return nil, err
+	}
+	w := &AtomicWriter{path: path, f: f}
+	// Chown may clear the setuid and setgid bits, so the mode comes after.
+	if chown {
+		err = f.Chown(uid, gid)
+	}
+	if err == nil && chmod {
+		err = f.Chmod(perm)
+	}
+	if err != nil {
+		w.Abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+// WriteFileAtomic writes data to the file at path like WriteFile, but
+// atomically, as an AtomicWriter does. If the file does not exist, it is
+// created with permissions perm (before umask); otherwise the new file
+// keeps the permission bits of the old one.
+func (path Path) WriteFileAtomic(data []byte, perm fs.FileMode) error {
+	w, err := path.CreateAtomicWith(AtomicOptions{Perm: perm, KeepMode: true})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Abort()
+		return err
+	}
+	return w.Close()
+}
+
+// Write writes p to the temporary file. Once a Write has failed, Close
+// does not replace the file.
+func (w *AtomicWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, errAtomicDone
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.f.Write(p)
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// Close flushes the temporary file to stable storage, renames it over the
+// file being replaced and flushes their directory. If a Write failed or
+// any step up to the rename fails, the temporary file is removed, the file
+// being replaced is left untouched, and the error is returned. If only
+// flushing the directory fails, the file has been replaced and the error
+// is a *DirSyncError.
+func (w *AtomicWriter) Close() error {
+	if w.done {
+		return errAtomicDone
+	}
+	err := w.err
+	if err == nil {
+		err = w.f.Sync()
+	}
+	if err == nil {
+		err = w.f.Close()
+		if err == nil {
+			err = Path(w.f.Name()).Rename(w.path)
+		}
+	}
+	if err != nil {
+		w.Abort()
+		return err
+	}
+	w.done = true
+	if err := syncDir(w.path.Dir()); err != nil {
+		return &DirSyncError{Path: w.path, Err: err}
+	}
+	return nil
+}
+
+// Abort removes the temporary file without replacing the file. It does
+// nothing if the AtomicWriter was already closed or aborted.
+func (w *AtomicWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+	w.done = true
+	w.f.Close()
+	return Path(w.f.Name()).Remove()
+}
+
+// createTemp creates a new file in dir, named by replacing the last "*" in
+// pattern with a random string as CreateTemp does, but with the permissions
+// perm (before umask) rather than 0600.
+func createTemp(dir Path, pattern string, perm fs.FileMode) (*os.File, error) {
+	prefix, suffix := pattern, ""
+	if i := strings.LastIndex(pattern, "*"); i >= 0 {
+		prefix, suffix = pattern[:i], pattern[i+1:]
+	}
+	for try := 0; ; try++ {
+		name := dir.Join(Path(prefix + nextRandom() + suffix))
+		f, err := name.OpenFile(os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, fs.ErrExist) && try < 10000 {
+			continue
+		}
+		return f, err
+	}
+}
+
+var (
+	randMu   sync.Mutex
+	randSeed uint32
+)
+
+// nextRandom returns a random string of digits for createTemp, as the
+// names of CreateTemp are made.
+func nextRandom() string {
+	randMu.Lock()
+	r := randSeed
+	if r == 0 {
+		r = uint32(time.Now().UnixNano() + int64(os.Getpid()))
+	}
+	r = r*1664525 + 1013904223 // constants from Numerical Recipes
+	randSeed = r
+	randMu.Unlock()
+	return strconv.Itoa(int(1e9 + r%1e9))[1:]
+}
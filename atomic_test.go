@@ -0,0 +1,117 @@
+package pathtype_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	p := tmpDir.Join("config")
+
+	err1 := p.WriteFileAtomic([]byte("one"), 0600)
+	info1, _ := p.Stat()
+	p.Chmod(0640)
+	err2 := p.WriteFileAtomic([]byte("two"), 0600)
+	info2, _ := p.Stat()
+	data, _ := p.ReadFile()
+	entries, _ := tmpDir.ReadDir()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, fs.FileMode(0600), nil, fs.FileMode(0640), "two", 1)
+	t1.Result(err1, info1.Mode(), err2, info2.Mode(), string(data), len(entries))
+	t1.AssertEquals()
+}
+
+func TestWriteFileAtomicUmask(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	if err := tmpDir.Join("plain").WriteFile(nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	expect, _ := tmpDir.Join("plain").Stat()
+
+	err1 := tmpDir.Join("atomic").WriteFileAtomic(nil, 0666)
+	info, _ := tmpDir.Join("atomic").Stat()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, expect.Mode())
+	t1.Result(err1, info.Mode())
+	t1.AssertEquals()
+}
+
+func TestCreateAtomic(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	p := tmpDir.Join("config")
+	if err := p.WriteFile([]byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := p.CreateAtomic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("new "))
+	w.Write([]byte("data"))
+	during, _ := p.ReadFile()
+	entriesDuring, _ := tmpDir.ReadDir()
+	errClose := w.Close()
+	after, _ := p.ReadFile()
+	info, _ := p.Stat()
+	_, errWrite := w.Write([]byte("more"))
+
+	w, err = p.CreateAtomicWith(pt.AtomicOptions{Perm: 0604, KeepOwner: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("aborted"))
+	errAbort := w.Abort()
+	aborted, _ := p.ReadFile()
+	entries, _ := tmpDir.ReadDir()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect("old", 2, nil, "new data", fs.FileMode(0600), true, nil, "new data", 1)
+	t1.Result(string(during), len(entriesDuring), errClose, string(after), info.Mode(), errWrite != nil,
+		errAbort, string(aborted), len(entries))
+	t1.AssertEquals()
+}
+
+func TestCreateAtomicKeepOwner(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	p := tmpDir.Join("config")
+	if err := p.WriteFileAtomic(nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := p.CreateAtomicWith(pt.AtomicOptions{Perm: 0604, KeepOwner: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errClose := w.Close()
+	info, _ := p.Stat()
+	_, errMissing := tmpDir.Join("missing/config").CreateAtomic()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, fs.FileMode(0604), true)
+	t1.Result(errClose, info.Mode(), errors.Is(errMissing, os.ErrNotExist))
+	t1.AssertEquals()
+}
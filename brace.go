@@ -0,0 +1,247 @@
+package pathtype
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxBraceExpansions limits the number of patterns a single brace
+// expression may expand to.
+const maxBraceExpansions = 1 << 16
+
+// expandBraces expands the brace expressions in pattern, returning the
+// resulting patterns in order. It understands comma separated alternatives
+// such as "{a,b}", which may be nested, and numeric ranges such as "{1..10}",
+// "{01..10}" or "{10..1..2}". A brace expression with neither a comma nor a
+// range, such as "{}" or "{a}", is kept literally. Braces inside character
+// classes and, if escape is true, characters preceded by a backslash are not
+// interpreted.
+//
+// An unmatched brace, or an expression that expands to too many patterns,
+// yields filepath.ErrBadPattern.
+func expandBraces(pattern string, escape bool) ([]string, error) {
+	open := -1
+	for i := 0; i < len(pattern) && open < 0; i++ {
+		switch pattern[i] {
+		case '\\':
+			if escape {
+				i++
+			}
+		case '[':
+			i = skipClass(pattern, i, escape)
+		case '{':
+			open = i
+		case '}':
+			return nil, filepath.ErrBadPattern
+		}
+	}
+	if open < 0 {
+		return []string{pattern}, nil
+	}
+	end := matchingBrace(pattern, open, escape)
+	if end < 0 {
+		return nil, filepath.ErrBadPattern
+	}
+	prefix, body, suffix := pattern[:open], pattern[open+1:end], pattern[end+1:]
+
+	var items []string
+	if alts := splitAlternatives(body, escape); len(alts) > 1 {
+		for _, alt := range alts {
+			exp, err := expandBraces(alt, escape)
+			if err != nil {
+				return nil, err
+			}
+			// Check as the alternatives accumulate, so that many large
+			// alternatives fail before they are all built.
+			if len(items)+len(exp) > maxBraceExpansions {
+				return nil, filepath.ErrBadPattern
+			}
+			items = append(items, exp...)
+		}
+	} else if r, ok, err := expandRange(body); ok {
+		if err != nil {
+			return nil, err
+		}
+		items = r
+	} else {
+		exp, err := expandBraces(body, escape)
+		if err != nil {
+			return nil, err
+		}
+		if len(exp) > maxBraceExpansions {
+			return nil, filepath.ErrBadPattern
+		}
+		for _, e := range exp {
+			items = append(items, "{"+e+"}")
+		}
+	}
+
+	rest, err := expandBraces(suffix, escape)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 && len(items) > maxBraceExpansions/len(rest) {
+		return nil, filepath.ErrBadPattern
+	}
+	res := make([]string, 0, len(items)*len(rest))
+	for _, item := range items {
+		for _, r := range rest {
+			res = append(res, prefix+item+r)
+		}
+	}
+	return res, nil
+}
+
+// skipClass returns the index of the ']' closing the character class
+// opened at pattern[i], or i if the class is never closed.
+func skipClass(pattern string, i int, escape bool) int {
+	for j := i + 1; j < len(pattern); j++ {
+		switch pattern[j] {
+		case '\\':
+			if escape {
+				j++
+			}
+		case ']':
+			return j
+		}
+	}
+	return i
+}
+
+// matchingBrace returns the index of the '}' closing the brace opened at
+// pattern[open], or -1 if there is none.
+func matchingBrace(pattern string, open int, escape bool) int {
+	depth := 0
+	for i := open; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if escape {
+				i++
+			}
+		case '[':
+			i = skipClass(pattern, i, escape)
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitAlternatives splits the body of a brace expression at the commas
+// that are not nested inside another brace expression.
+func splitAlternatives(body string, escape bool) []string {
+	var alts []string
+	depth, start := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			if escape {
+				i++
+			}
+		case '[':
+			i = skipClass(body, i, escape)
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				alts = append(alts, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(alts, body[start:])
+}
+
+// expandRange expands a numeric range of the form "lo..hi" or
+// "lo..hi..step". If either bound has a leading zero, every number is
+// zero-padded to the width of the wider bound. The boolean result reports
+// whether body is a range at all.
+func expandRange(body string) ([]string, bool, error) {
+	parts := strings.Split(body, "..")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, false, nil
+	}
+	lo, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, false, nil
+	}
+	hi, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, false, nil
+	}
+	step := 1
+	if len(parts) == 3 {
+		if step, err = strconv.Atoi(parts[2]); err != nil {
+			return nil, false, nil
+		}
+	}
+	// Count the elements in uint64, which holds the distance between any
+	// two ints, so that neither the count nor the loop can overflow.
+	ustep := uint64(step)
+	if step < 0 {
+		ustep = -ustep
+	}
+	if ustep == 0 {
+		ustep = 1
+	}
+	var span uint64
+	if lo <= hi {
+		span = uint64(hi) - uint64(lo)
+	} else {
+		span = uint64(lo) - uint64(hi)
+	}
+	count := span / ustep
+	if count >= maxBraceExpansions {
+		return nil, true, filepath.ErrBadPattern
+	}
+
+	width := 0
+	if hasLeadingZero(parts[0]) || hasLeadingZero(parts[1]) {
+		width = len(parts[0])
+		if len(parts[1]) > width {
+			width = len(parts[1])
+		}
+	}
+	format := func(n int) string {
+		sign, digits := "", strconv.Itoa(n)
+		if n < 0 {
+			sign, digits = "-", digits[1:]
+		}
+		if pad := width - len(sign) - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		return sign + digits
+	}
+
+	res := make([]string, 0, count+1)
+	n := uint64(lo)
+	for i := uint64(0); i <= count; i++ {
+		res = append(res, format(int(int64(n))))
+		if lo <= hi {
+			n += ustep
+		} else {
+			n -= ustep
+		}
+	}
+	return res, true, nil
+}
+
+func hasLeadingZero(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	return len(s) > 1 && s[0] == '0'
+}
+
+// expandOSBraces expands the brace expressions in a pattern for Match,
+// using the same escaping rules as filepath.Match.
+func expandOSBraces(pattern string) ([]string, error) {
+	return expandBraces(pattern, runtime.GOOS != "windows")
+}
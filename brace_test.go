@@ -0,0 +1,122 @@
+package pathtype_test
+
+import (
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var braceMatchTests = []struct {
+	pattern string
+	name    path
+	match   bool
+	err     error
+}{
+	{"*.{go,tmpl}", "main.go", true, nil},
+	{"*.{go,tmpl}", "index.tmpl", true, nil},
+	{"*.{go,tmpl}", "index.html", false, nil},
+	{"src/{api,web}/*.{go,tmpl}", "src/web/page.tmpl", true, nil},
+	{"src/{api,web}/*.{go,tmpl}", "src/cli/main.go", false, nil},
+	{"{a,b{c,d}}x", "bdx", true, nil},
+	{"{a,b{c,d}}x", "bx", false, nil},
+	{"{a,}x", "x", true, nil},
+	{"log{1..10}", "log7", true, nil},
+	{"log{1..10}", "log10", true, nil},
+	{"log{1..10}", "log11", false, nil},
+	{"log{01..10}", "log07", true, nil},
+	{"log{01..10}", "log7", false, nil},
+	{"log{10..0..5}", "log5", true, nil},
+	{"log{10..0..5}", "log4", false, nil},
+	{"log{-2..2}", "log-1", true, nil},
+	{"{}", "{}", true, nil},
+	{"{a}", "{a}", true, nil},
+	{"{a..}", "{a..}", true, nil},
+	{"[{]a", "{a", true, nil},
+	{"\\{a,b\\}", "{a,b}", true, nil},
+	{"{a,b", "a", false, filepath.ErrBadPattern},
+	{"a,b}", "a", false, filepath.ErrBadPattern},
+	{"{a,b}}", "a", false, filepath.ErrBadPattern},
+	{"{a,[}", "b", false, filepath.ErrBadPattern},
+	{"x{0..100000000}", "x1", false, filepath.ErrBadPattern},
+	{"{9223372036854775800..9223372036854775807..5}", "9223372036854775805", true, nil},
+	{"{9223372036854775800..9223372036854775807..5}", "9223372036854775807", false, nil},
+	{"{9223372036854775807..9223372036854775800..5}", "9223372036854775802", true, nil},
+	{"{-9223372036854775808..0}", "x", false, filepath.ErrBadPattern},
+	{"{0..-9223372036854775808}", "x", false, filepath.ErrBadPattern},
+	{"{" + strings.Repeat("{0..65534},", 59) + "{0..65534}}", "x", false, filepath.ErrBadPattern},
+	{"{{0..65535},x}", "x", false, filepath.ErrBadPattern},
+	{"{0..65535}", "65535", true, nil},
+}
+
+func TestMatchBraces(t *testing.T) {
+	for _, test := range braceMatchTests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.match, test.err)
+		t1.Result(test.name.Match(test.pattern))
+		t1.AssertEquals()
+	}
+}
+
+func TestGlobBraces(t *testing.T) {
+	tmpDir := prepareGlobTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	tests := []struct {
+		pattern string
+		expect  []path
+		err     error
+	}{
+		{"{dir,other}/*_test.go", []path{"dir/b_test.go", "other/f_test.go"}, nil},
+		{"{other,dir}/*_test.go", []path{"other/f_test.go", "dir/b_test.go"}, nil},
+		{"*.{go,txt}", []path{"a.go", "a_test.go"}, nil},
+		{"{a,a_test}.go", []path{"a.go", "a_test.go"}, nil},
+		{"{*,a}.go", []path{"a.go", "a_test.go"}, nil},
+		{"{dir/**,other}/{c,f}_test.go", []path{"dir/to/c_test.go", "other/f_test.go"}, nil},
+		{"{dir,other", nil, filepath.ErrBadPattern},
+	}
+	for _, test := range tests {
+		var expect []path
+		for _, p := range test.expect {
+			expect = append(expect, tmpDir.Join(p))
+		}
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathToString(expect), test.err)
+		t1.Result(tmpDir.Glob(test.pattern))
+		t1.AssertEquals()
+
+		t2 := tester{TB: t, Transform: pathToString}
+		t2.Expect(pathToString(expect), test.err)
+		t2.Result(tmpDir.GlobAll(test.pattern))
+		t2.AssertEquals()
+	}
+}
+
+func TestGlobFSBraces(t *testing.T) {
+	braceFsys := fstest.MapFS{
+		"src/api/main.go":    {},
+		"src/api/page.tmpl":  {},
+		"src/web/page.tmpl":  {},
+		"src/web/style.css":  {},
+		"src/cli/main.go":    {},
+		"src/api/{x}/a.go":   {},
+		"src/api/{x}/b.tmpl": {},
+	}
+	tests := []struct {
+		pattern string
+		expect  []path
+		err     error
+	}{
+		{"src/{api,web}/*.{go,tmpl}", []path{"src/api/main.go", "src/api/page.tmpl", "src/web/page.tmpl"}, nil},
+		{"src/api/\\{x\\}/*", []path{"src/api/{x}/a.go", "src/api/{x}/b.tmpl"}, nil},
+		{"src/api/{x}/*.go", []path{"src/api/{x}/a.go"}, nil},
+		{"src/{api", nil, pathpkg.ErrBadPattern},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathToString(test.expect), test.err)
+		t1.Result(path("").GlobFS(braceFsys, test.pattern))
+		t1.AssertEquals()
+	}
+}
@@ -0,0 +1,120 @@
+package pathtype
+
+import (
+	"os"
+	"strings"
+)
+
+// Anchor returns the part of path that makes it absolute or rooted: the
+// volume name, if any, followed by a separator if path is rooted. It is
+// "/" for "/usr/bin", "" for "usr/bin", and `C:\` for `C:\Windows` and
+// `\\host\share\` for `\\host\share\dir` on Windows.
+func (path Path) Anchor() Path {
+	anchor, _ := path.Clean().splitAnchor()
+	return anchor
+}
+
+// Parts returns the anchor of path, if it is not empty, followed by the
+// elements of path, after cleaning it. It returns no parts for ".", so
+// that it is the inverse of Join for relative paths.
+func (path Path) Parts() []Path {
+	anchor, elems := path.Clean().splitAnchor()
+	var parts []Path
+	if anchor != "" {
+		parts = append(parts, anchor)
+	}
+	for _, e := range elems {
+		parts = append(parts, Path(e))
+	}
+	return parts
+}
+
+// Parents returns the successive directories that contain path, after
+// cleaning it, from the closest to the farthest: "a/b", "a", "." for
+// "a/b/c", and "/a/b", "/a", "/" for "/a/b/c". The parents of a relative
+// path stop at its leading ".." elements: ".." for "../a", and none for "..".
+func (path Path) Parents() []Path {
+	var parents []Path
+	for p := path.Clean(); p.Depth() > 0 && p.Base() != ".."; {
+		p = p.Dir()
+		parents = append(parents, p)
+	}
+	return parents
+}
+
+// Depth returns the number of elements of path after the anchor, after
+// cleaning it: 0 for "." and "/", 2 for "a/b" and "/a/b".
+func (path Path) Depth() int {
+	_, elems := path.Clean().splitAnchor()
+	return len(elems)
+}
+
+// HasPrefixPath reports whether prefix is path or one of its parents,
+// comparing whole elements after cleaning both, so that "/foo" is a prefix
+// of "/foo/bar" but not of "/foobar".
+func (path Path) HasPrefixPath(prefix Path) bool {
+	_, ok := path.trimPrefixPath(prefix)
+	return ok
+}
+
+// IsSubpathOf reports whether path is inside the directory base, comparing
+// whole elements after cleaning both. Unlike HasPrefixPath, it reports
+// false if path and base are the same.
+func (path Path) IsSubpathOf(base Path) bool {
+	rest, ok := path.trimPrefixPath(base)
+	return ok && len(rest) > 0
+}
+
+// TrimPrefixPath returns path relative to prefix if HasPrefixPath reports
+// that prefix is a prefix of path, and "." if they are the same. Otherwise
+// it returns path unchanged.
+func (path Path) TrimPrefixPath(prefix Path) Path {
+	rest, ok := path.trimPrefixPath(prefix)
+	switch {
+	case !ok:
+		return path
+	case len(rest) == 0:
+		return "."
+	}
+	return Path(strings.Join(rest, string(os.PathSeparator)))
+}
+
+// trimPrefixPath returns the elements of path after those of prefix, and
+// whether prefix is a prefix of path. A relative path that goes up out of
+// prefix, such as "../x" for ".", does not have prefix as a prefix.
+func (path Path) trimPrefixPath(prefix Path) ([]string, bool) {
+	anchor, elems := path.Clean().splitAnchor()
+	pAnchor, pElems := prefix.Clean().splitAnchor()
+	if anchor != pAnchor || len(pElems) > len(elems) {
+		return nil, false
+	}
+	for i, e := range pElems {
+		if elems[i] != e {
+			return nil, false
+		}
+	}
+	rest := elems[len(pElems):]
+	if len(rest) > 0 && rest[0] == ".." {
+		return nil, false
+	}
+	return rest, true
+}
+
+// splitAnchor splits the clean path into its anchor and its elements.
+func (path Path) splitAnchor() (anchor Path, elems []string) {
+	vol := path.VolumeName()
+	rest := path[len(vol):]
+	anchor = vol
+	if len(rest) > 0 && os.IsPathSeparator(rest[0]) {
+		anchor += rest[:1]
+	}
+	if rest == "." {
+		return anchor, nil
+	}
+	return anchor, splitSeparators(string(rest))
+}
+
+// splitSeparators splits s around separators, dropping empty elements.
+func splitSeparators(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r < 0x80 && os.IsPathSeparator(uint8(r)) })
+}
@@ -0,0 +1,65 @@
+package pathtype_test
+
+import (
+	"testing"
+)
+
+func TestParts(t *testing.T) {
+	tests := []struct {
+		path    path
+		anchor  string
+		parts   []string
+		parents []string
+		depth   int
+	}{
+		{"", "", nil, nil, 0},
+		{".", "", nil, nil, 0},
+		{"/", "/", []string{"/"}, nil, 0},
+		{"a", "", []string{"a"}, []string{"."}, 1},
+		{"a/b/c", "", []string{"a", "b", "c"}, []string{"a/b", "a", "."}, 3},
+		{"/a/b/c", "/", []string{"/", "a", "b", "c"}, []string{"/a/b", "/a", "/"}, 3},
+		{"//a/./b/../c/", "/", []string{"/", "a", "c"}, []string{"/a", "/"}, 2},
+		{"..", "", []string{".."}, nil, 1},
+		{"../a", "", []string{"..", "a"}, []string{".."}, 2},
+		{"../../a/b", "", []string{"..", "..", "a", "b"}, []string{"../../a", "../.."}, 4},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.anchor, test.parts, test.parents, test.depth)
+		t1.Result(test.path.Anchor(), test.path.Parts(), test.path.Parents(), test.path.Depth())
+		t1.AssertEquals()
+	}
+}
+
+func TestHasPrefixPath(t *testing.T) {
+	tests := []struct {
+		path, prefix path
+		hasPrefix    bool
+		isSubpath    bool
+		trimmed      string
+	}{
+		{"/foo/bar", "/foo", true, true, "bar"},
+		{"/foobar", "/foo", false, false, "/foobar"},
+		{"/foo", "/foo/", true, false, "."},
+		{"/foo/./bar/baz", "/foo/bar/", true, true, "baz"},
+		{"/foo/bar", "/", true, true, "foo/bar"},
+		{"/foo", "foo", false, false, "/foo"},
+		{"foo/bar", "foo", true, true, "bar"},
+		{"foo", ".", true, true, "foo"},
+		{"/foo", ".", false, false, "/foo"},
+		{"foo", "foo/bar", false, false, "foo"},
+		{"foo/../bar", "foo", false, false, "foo/../bar"},
+		{"../foo", "..", true, true, "foo"},
+		{"..", ".", false, false, ".."},
+		{"../etc", ".", false, false, "../etc"},
+		{"../x", ".", false, false, "../x"},
+		{"../../x", "..", false, false, "../../x"},
+		{"../..", "..", false, false, "../.."},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.hasPrefix, test.isSubpath, test.trimmed)
+		t1.Result(test.path.HasPrefixPath(test.prefix), test.path.IsSubpathOf(test.prefix), test.path.TrimPrefixPath(test.prefix))
+		t1.AssertEquals()
+	}
+}
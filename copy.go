@@ -0,0 +1,407 @@
+package pathtype
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SymlinkPolicy selects what CopyFile and CopyTree do with symbolic links.
+type SymlinkPolicy int
+
+const (
+	// CopySymlinks copies a symbolic link as a link to the same destination.
+	CopySymlinks SymlinkPolicy = iota
+	// FollowSymlinks copies the file or directory a symbolic link points to.
+	FollowSymlinks
+)
+
+// OverwritePolicy selects what CopyFile and CopyTree do when a file being
+// copied already exists at the destination.
+type OverwritePolicy int
+
+const (
+	// OverwriteError fails with an error wrapping fs.ErrExist.
+	OverwriteError OverwritePolicy = iota
+	// OverwriteSkip leaves the existing file alone.
+	OverwriteSkip
+	// OverwriteAlways replaces the existing file.
+	OverwriteAlways
+	// OverwriteIfNewer replaces the existing file if the source was
+	// modified more recently, and otherwise leaves it alone.
+	OverwriteIfNewer
+)
+
+// CopyOptions configures CopyFile and CopyTree.
+type CopyOptions struct {
+	// PreserveMode gives copies the exact permission bits of the source,
+	// including the setuid, setgid and sticky bits. Otherwise copies are
+	// created with the permission bits of the source, minus the umask.
+	PreserveMode bool
+
+	// PreserveOwner gives copies the owner and group of the source. It
+	// usually requires special privileges, and does nothing on Windows
+	// and Plan 9.
+	PreserveOwner bool
+
+	// PreserveTimes gives copies the modification time of the source. The
+	// access time is set to the same value, as it cannot be read portably.
+	// The times of symbolic links are not preserved.
+	PreserveTimes bool
+
+	// Symlinks is the way symbolic links are copied.
+	Symlinks SymlinkPolicy
+
+	// Overwrite is the way existing files at the destination are treated.
+	// Existing directories are always merged with the source directories.
+	Overwrite OverwritePolicy
+
+	// Sync flushes every copied file and directory to stable storage
+	// before CopyFile or CopyTree returns.
+	Sync bool
+
+	// Filter, if non-nil, is called by CopyTree for every file and
+	// directory below the root, with its source path. If it returns false,
+	// the file, or the directory and everything in it, is not copied.
+	Filter func(p Path, d fs.DirEntry) bool
+}
+
+// CopyFailure describes one file that CopyTree failed to copy.
+type CopyFailure struct {
+	Path Path  // the source path
+	Err  error // the problem
+}
+
+// CopyError is the error returned by CopyTree when some files could not be
+// copied. CopyTree copies everything it can before returning it.
+type CopyError struct {
+	Failures []CopyFailure
+}
+
+func (e *CopyError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = string(f.Path) + ": " + f.Err.Error()
+	}
+	return fmt.Sprintf("copy: %d failed: %s", len(e.Failures), strings.Join(msgs, "; "))
+}
+
+var (
+	errCopyDir       = errors.New("is a directory")
+	errCopyInside    = errors.New("destination is inside the source tree")
+	errCopyIrregular = errors.New("not a regular file, directory or symbolic link")
+)
+
+// CopyFile copies the file at path to dst, as configured by opts.
+// If path is a symbolic link, it is copied as a link or followed according
+// to opts.Symlinks. CopyFile does not copy directories; see CopyTree.
+// If there is an error, it will be of type *os.PathError.
+func (path Path) CopyFile(dst Path, opts CopyOptions) error {
+	info, err := opts.stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return &fs.PathError{Op: "copy", Path: string(path), Err: errCopyDir}
+	}
+	return copyEntry(path, dst, info, opts)
+}
+
+// CopyTree copies the file tree rooted at path to dst, as configured by
+// opts. If path is a directory, dst is created if needed and receives the
+// contents of path, so that dst.Join(rel) is the copy of path.Join(rel).
+// Otherwise CopyTree behaves like CopyFile.
+//
+// CopyTree does not stop at the first problem. It copies everything it
+// can and then returns a *CopyError listing every source path that failed.
+// Symbolic links are followed as WalkFollow does when opts.Symlinks is
+// FollowSymlinks, so a link back up the tree is reported as a failure.
+func (path Path) CopyTree(dst Path, opts CopyOptions) error {
+	info, err := opts.stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyEntry(path, dst, info, opts)
+	}
+	if inside, err := isInside(dst, path); err != nil {
+		return err
+	} else if inside {
+		return &fs.PathError{Op: "copy", Path: string(dst), Err: errCopyInside}
+	}
+
+	c := &treeCopy{root: path, dst: dst, opts: opts}
+	if opts.Symlinks == FollowSymlinks {
+		err = path.WalkFollow(FollowOptions{}, c.visit)
+	} else {
+		err = path.WalkDir(c.visit)
+	}
+	if err != nil {
+		c.fail(path, err)
+	}
+	// Directory metadata is set last, since copying into a directory
+	// changes its modification time and may need write permission.
+	for i := len(c.dirs) - 1; i >= 0; i-- {
+		d := c.dirs[i]
+		err := setMetadata(d.dst, d.info, d.forceMode, opts)
+		if err == nil && opts.Sync {
+			err = syncDir(d.dst)
+		}
+		if err != nil {
+			c.fail(d.src, err)
+		}
+	}
+	if len(c.failures) > 0 {
+		return &CopyError{Failures: c.failures}
+	}
+	return nil
+}
+
+// treeCopy holds the state of CopyTree.
+type treeCopy struct {
+	root     Path
+	dst      Path
+	opts     CopyOptions
+	dirs     []copiedDir
+	failures []CopyFailure
+}
+
+// copiedDir is a directory whose metadata is still to be set.
+type copiedDir struct {
+	src, dst  Path
+	info      fs.FileInfo
+	forceMode bool
+}
+
+func (c *treeCopy) fail(p Path, err error) {
+	c.failures = append(c.failures, CopyFailure{Path: p, Err: err})
+}
+
+func (c *treeCopy) visit(p Path, d fs.DirEntry, err error) error {
+	if err != nil {
+		c.fail(p, err)
+		return nil
+	}
+	if p != c.root && c.opts.Filter != nil && !c.opts.Filter(p, d) {
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	rel, err := c.root.Rel(p)
+	if err != nil {
+		c.fail(p, err)
+		return nil
+	}
+	dst := c.dst.Join(rel)
+	info, err := d.Info()
+	if err != nil {
+		c.fail(p, err)
+		return nil
+	}
+	if !d.IsDir() {
+		if err := copyEntry(p, dst, info, c.opts); err != nil {
+			c.fail(p, err)
+		}
+		return nil
+	}
+
+	// Make sure the directory can be written to while it is being filled.
+	perm := info.Mode().Perm()
+	dinfo, err := dst.Lstat()
+	switch {
+	case err == nil && !dinfo.IsDir():
+		err = &fs.PathError{Op: "copy", Path: string(dst), Err: fs.ErrExist}
+	case err == nil:
+	case errors.Is(err, fs.ErrNotExist):
+		err = dst.Mkdir(perm | 0700)
+	}
+	if err != nil {
+		c.fail(p, err)
+		return filepath.SkipDir
+	}
+	c.dirs = append(c.dirs, copiedDir{src: p, dst: dst, info: info, forceMode: dinfo == nil && perm|0700 != perm})
+	return nil
+}
+
+// stat returns the FileInfo of the source path p.
+func (opts CopyOptions) stat(p Path) (fs.FileInfo, error) {
+	if opts.Symlinks == FollowSymlinks {
+		return p.Stat()
+	}
+	return p.Lstat()
+}
+
+// copyEntry copies the file or symbolic link src, described by info, to dst.
+func copyEntry(src, dst Path, info fs.FileInfo, opts CopyOptions) error {
+	symlink := info.Mode()&fs.ModeSymlink != 0
+	if !symlink && !info.Mode().IsRegular() {
+		return &fs.PathError{Op: "copy", Path: string(src), Err: errCopyIrregular}
+	}
+	ok, replace, err := checkOverwrite(dst, info, opts.Overwrite)
+	if !ok || err != nil {
+		return err
+	}
+	if symlink {
+		return copySymlink(src, dst, replace, info, opts)
+	}
+	return copyRegular(src, dst, replace, info, opts)
+}
+
+// checkOverwrite reports whether the source described by info should be
+// copied to dst, and whether it replaces a file already at dst.
+func checkOverwrite(dst Path, info fs.FileInfo, policy OverwritePolicy) (ok, replace bool, err error) {
+	dinfo, err := dst.Lstat()
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	if dinfo.IsDir() {
+		return false, false, &fs.PathError{Op: "copy", Path: string(dst), Err: errCopyDir}
+	}
+	switch policy {
+	case OverwriteSkip:
+		return false, false, nil
+	case OverwriteAlways:
+	case OverwriteIfNewer:
+		if !info.ModTime().After(dinfo.ModTime()) {
+			return false, false, nil
+		}
+	default:
+		return false, false, &fs.PathError{Op: "copy", Path: string(dst), Err: fs.ErrExist}
+	}
+	return true, true, nil
+}
+
+// tempName returns an unused name in the directory of dst for a copy that
+// will be renamed over dst. Renaming rather than writing through dst keeps
+// the old file until the copy is complete, and replaces a symbolic link
+// or a hard link instead of the file it leads to.
+func tempName(dst Path) (Path, error) {
+	f, err := createTemp(dst.Dir(), "."+string(dst.Base())+".tmp*", 0600)
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	tmp := Path(f.Name())
+	return tmp, tmp.Remove()
+}
+
+func copySymlink(src, dst Path, replace bool, info fs.FileInfo, opts CopyOptions) error {
+	target, err := src.Readlink()
+	if err != nil {
+		return err
+	}
+	link := dst
+	if replace {
+		if link, err = tempName(dst); err != nil {
+			return err
+		}
+	}
+	if err := target.Symlink(link); err != nil {
+		return err
+	}
+	if opts.PreserveOwner {
+		if uid, gid, ok := fileOwner(info); ok {
+			err = link.Lchown(uid, gid)
+		}
+	}
+	if err == nil && replace {
+		err = link.Rename(dst)
+	}
+	if err != nil && replace {
+		link.Remove()
+	}
+	return err
+}
+
+func copyRegular(src, dst Path, replace bool, info fs.FileInfo, opts CopyOptions) (err error) {
+	in, err := src.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	var out *os.File
+	if replace {
+		out, err = createTemp(dst.Dir(), "."+string(dst.Base())+".tmp*", info.Mode().Perm())
+	} else {
+		out, err = dst.OpenFile(os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	}
+	if err != nil {
+		return err
+	}
+	tmp := Path(out.Name())
+	if _, err = io.Copy(out, in); err == nil && opts.Sync {
+		err = out.Sync()
+	}
+	if err == nil {
+		err = out.Close()
+	} else {
+		out.Close()
+	}
+	if err != nil {
+		tmp.Remove()
+		return err
+	}
+	if err := setMetadata(tmp, info, false, opts); err != nil || !replace {
+		if replace {
+			tmp.Remove()
+		}
+		return err
+	}
+	if err := tmp.Rename(dst); err != nil {
+		tmp.Remove()
+		return err
+	}
+	return nil
+}
+
+// setMetadata gives the copy dst the metadata of the source described by
+// info that opts asks to preserve. If forceMode is true, the permission bits
+// are set even if opts does not ask for them.
+func setMetadata(dst Path, info fs.FileInfo, forceMode bool, opts CopyOptions) error {
+	if opts.PreserveOwner {
+		if uid, gid, ok := fileOwner(info); ok {
+			if err := dst.Lchown(uid, gid); err != nil {
+				return err
+			}
+		}
+	}
+	// Chown may clear the setuid and setgid bits, so the mode comes after.
+	if opts.PreserveMode {
+		if err := dst.Chmod(info.Mode() & (fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky)); err != nil {
+			return err
+		}
+	} else if forceMode {
+		if err := dst.Chmod(info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if opts.PreserveTimes {
+		return dst.Chtimes(info.ModTime(), info.ModTime())
+	}
+	return nil
+}
+
+// isInside reports whether the path p is the directory dir or inside it.
+func isInside(p, dir Path) (bool, error) {
+	absP, err := p.Abs()
+	if err != nil {
+		return false, err
+	}
+	absDir, err := dir.Abs()
+	if err != nil {
+		return false, err
+	}
+	rel, err := absDir.Rel(absP)
+	if err != nil {
+		return false, nil
+	}
+	return rel != ".." && !strings.HasPrefix(string(rel), ".."+string(filepath.Separator)), nil
+}
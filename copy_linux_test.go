@@ -0,0 +1,29 @@
+package pathtype_test
+
+import (
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func TestCopyFileFailureKeepsDestination(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	dst := tmpDir.Join("dst")
+	if err := dst.WriteFile([]byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// /proc/self/mem is a regular file whose first bytes cannot be read.
+	err = path("/proc/self/mem").CopyFile(dst, pt.CopyOptions{Overwrite: pt.OverwriteAlways})
+	data, _ := dst.ReadFile()
+	entries, _ := tmpDir.ReadDir()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(true, "old", 1)
+	t1.Result(err != nil, string(data), len(entries))
+	t1.AssertEquals()
+}
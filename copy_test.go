@@ -0,0 +1,204 @@
+package pathtype_test
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func prepareCopyTestTree(t *testing.T) path {
+	t.Helper()
+	tmpDir, err := prepareTestDirTree("src/a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[path]string{
+		"src/f.txt":     "f",
+		"src/a/g.txt":   "g",
+		"src/a/b/h.txt": "h",
+		"src/a/skip.me": "skip",
+	}
+	for p, data := range files {
+		if err := tmpDir.Join(p).WriteFile([]byte(data), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := path("f.txt").Symlink(tmpDir.Join("src/link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpDir.Join("src/a/b").Chmod(0550); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	for _, p := range []path{"src/f.txt", "src/a"} {
+		if err := tmpDir.Join(p).Chtimes(mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+// listTree returns a description of every file below root.
+func listTree(t *testing.T, root path) []string {
+	t.Helper()
+	var res []string
+	err := root.WalkDir(func(p path, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := root.Rel(p)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		s := string(rel.ToSlash()) + " " + info.Mode().String()
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			target, _ := p.Readlink()
+			s += " -> " + string(target)
+		case d.Type().IsRegular():
+			data, _ := p.ReadFile()
+			s += " " + string(data)
+		}
+		res = append(res, s)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func TestCopyFile(t *testing.T) {
+	tmpDir := prepareCopyTestTree(t)
+	defer func() {
+		tmpDir.Join("src/a/b").Chmod(0755)
+		tmpDir.RemoveAll()
+	}()
+	src := tmpDir.Join("src/f.txt")
+	dst := tmpDir.Join("f.txt")
+
+	err1 := src.CopyFile(dst, pt.CopyOptions{PreserveMode: true, PreserveTimes: true})
+	data, _ := dst.ReadFile()
+	info, _ := dst.Stat()
+	err2 := src.CopyFile(dst, pt.CopyOptions{})
+	tmpDir.Join("src/link").WriteFile(nil, 0644) // a write through the link, to f.txt
+	err3 := src.CopyFile(dst, pt.CopyOptions{Overwrite: pt.OverwriteSkip})
+	data3, _ := dst.ReadFile()
+	err4 := src.CopyFile(dst, pt.CopyOptions{Overwrite: pt.OverwriteIfNewer})
+	data4, _ := dst.ReadFile()
+	err5 := src.CopyFile(dst, pt.CopyOptions{Overwrite: pt.OverwriteAlways})
+	data5, _ := dst.ReadFile()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect("f", fs.FileMode(0640), time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC), nil, true, nil, "f", nil, "", nil, "")
+	t1.Result(string(data), info.Mode(), info.ModTime().UTC(), err1, errors.Is(err2, fs.ErrExist),
+		err3, string(data3), err4, string(data4), err5, string(data5))
+	t1.AssertEquals()
+
+	err := tmpDir.Join("src").CopyFile(tmpDir.Join("dir"), pt.CopyOptions{})
+	if err == nil {
+		t.Error("CopyFile of a directory: expected an error")
+	}
+}
+
+func TestCopyFileSymlink(t *testing.T) {
+	tmpDir := prepareCopyTestTree(t)
+	defer func() {
+		tmpDir.Join("src/a/b").Chmod(0755)
+		tmpDir.RemoveAll()
+	}()
+	src := tmpDir.Join("src/link")
+
+	err1 := src.CopyFile(tmpDir.Join("link"), pt.CopyOptions{})
+	target, _ := tmpDir.Join("link").Readlink()
+	err2 := src.CopyFile(tmpDir.Join("file"), pt.CopyOptions{Symlinks: pt.FollowSymlinks})
+	info, _ := tmpDir.Join("file").Lstat()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, "f.txt", nil, true)
+	t1.Result(err1, target, err2, info.Mode().IsRegular())
+	t1.AssertEquals()
+}
+
+func TestCopyTree(t *testing.T) {
+	tmpDir := prepareCopyTestTree(t)
+	defer func() {
+		for _, p := range []path{"src/a/b", "dst/a/b", "follow/a/b"} {
+			tmpDir.Join(p).Chmod(0755)
+		}
+		tmpDir.RemoveAll()
+	}()
+	src := tmpDir.Join("src")
+
+	filter := func(p path, d fs.DirEntry) bool { return p.Ext() != ".me" }
+	err := src.CopyTree(tmpDir.Join("dst"), pt.CopyOptions{PreserveTimes: true, Filter: filter})
+	info, _ := tmpDir.Join("dst/a").Stat()
+	var expect []string
+	for _, s := range listTree(t, src) {
+		if !strings.HasPrefix(s, "a/skip.me ") {
+			expect = append(expect, s)
+		}
+	}
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, expect, time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC))
+	t1.Result(err, listTree(t, tmpDir.Join("dst")), info.ModTime().UTC())
+	t1.AssertEquals()
+
+	err = src.CopyTree(tmpDir.Join("follow"), pt.CopyOptions{Symlinks: pt.FollowSymlinks})
+	info, _ = tmpDir.Join("follow/link").Lstat()
+	t1 = tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, true)
+	t1.Result(err, info.Mode().IsRegular())
+	t1.AssertEquals()
+
+	err = src.CopyTree(src.Join("a/copy"), pt.CopyOptions{})
+	if err == nil {
+		t.Error("CopyTree into the source tree: expected an error")
+	}
+}
+
+func TestCopyTreeErrors(t *testing.T) {
+	tmpDir := prepareCopyTestTree(t)
+	defer func() {
+		tmpDir.Join("src/a/b").Chmod(0755)
+		tmpDir.RemoveAll()
+	}()
+	src := tmpDir.Join("src")
+	if err := path("nowhere").Symlink(src.Join("a/dangling")); err != nil {
+		t.Fatal(err)
+	}
+	if err := path("..").Symlink(src.Join("a/loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpDir.Join("dst/a").MkdirAll(0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpDir.Join("dst/a/g.txt").WriteFile(nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := src.CopyTree(tmpDir.Join("dst"), pt.CopyOptions{Symlinks: pt.FollowSymlinks})
+	var copyErr *pt.CopyError
+	var failed []string
+	if errors.As(err, &copyErr) {
+		for _, f := range copyErr.Failures {
+			rel, _ := src.Rel(f.Path)
+			failed = append(failed, string(rel.ToSlash()))
+		}
+	}
+	sort.Strings(failed)
+	data, _ := tmpDir.Join("dst/f.txt").ReadFile()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect([]string{"a/dangling", "a/g.txt", "a/loop"}, "f")
+	t1.Result(failed, string(data))
+	t1.AssertEquals()
+}
@@ -0,0 +1,112 @@
+package pathtype
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// ExpandOptions configures ExpandEnvWith.
+type ExpandOptions struct {
+	// Lookup returns the value of the variable named key and whether it
+	// is defined. If nil, os.LookupEnv is used.
+	Lookup func(key string) (string, bool)
+
+	// Strict makes ExpandEnvWith fail with an *UndefinedVarError when a
+	// variable is not defined, instead of replacing it with "".
+	Strict bool
+}
+
+// UndefinedVarError is the error returned by ExpandEnvWith in strict mode
+// when the path refers to a variable that is not defined.
+type UndefinedVarError struct {
+	Name string // the name of the variable
+	Path Path   // the path being expanded
+}
+
+func (e *UndefinedVarError) Error() string {
+	return "expand " + string(e.Path) + ": undefined variable $" + e.Name
+}
+
+// ExpandUser replaces a leading "~" in path with the current user's home
+// directory, as returned by UserHomeDir, and a leading "~name" with the
+// home directory of the user name, as found by os/user.Lookup. Paths that
+// do not start with "~" are returned unchanged.
+func (path Path) ExpandUser() (Path, error) {
+	s := string(path)
+	if !strings.HasPrefix(s, "~") {
+		return path, nil
+	}
+	i := 1
+	for i < len(s) && !os.IsPathSeparator(s[i]) {
+		i++
+	}
+	var home string
+	if name := s[1:i]; name == "" {
+		h, err := os.UserHomeDir()
+		if err != nil {
+			return path, err
+		}
+		home = h
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return path, err
+		}
+		home = u.HomeDir
+	}
+	return Path(home + s[i:]), nil
+}
+
+// ExpandEnv replaces ${var} or $var in path according to the values of the
+// current environment variables. References to undefined variables are
+// replaced by the empty string.
+func (path Path) ExpandEnv() Path {
+	return Path(os.ExpandEnv(string(path)))
+}
+
+// ExpandEnvWith replaces ${var} or $var in path like ExpandEnv, but looks
+// the variables up as configured by opts.
+func (path Path) ExpandEnvWith(opts ExpandOptions) (Path, error) {
+	lookup := opts.Lookup
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	var err error
+	res := os.Expand(string(path), func(key string) string {
+		v, ok := lookup(key)
+		if !ok && opts.Strict && err == nil {
+			err = &UndefinedVarError{Name: key, Path: path}
+		}
+		return v
+	})
+	if err != nil {
+		return path, err
+	}
+	return Path(res), nil
+}
+
+// ContractUser replaces the current user's home directory at the start of
+// path with "~", the reverse of ExpandUser, for display. It returns path
+// unchanged if it is not inside the home directory or the home directory
+// is unknown.
+func (path Path) ContractUser() Path {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	home = filepath.Clean(home)
+	if len(home) == len(filepath.VolumeName(home))+1 && os.IsPathSeparator(home[len(home)-1]) {
+		// Everything is inside a home directory at the root.
+		return path
+	}
+	s := string(path)
+	switch {
+	case s == home:
+		return "~"
+	case strings.HasPrefix(s, home) && os.IsPathSeparator(s[len(home)]):
+		return Path("~" + s[len(home):])
+	}
+	return path
+}
@@ -0,0 +1,52 @@
+package pathtype_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestExpandEnv(t *testing.T) {
+	setenv(t, "PATHTYPE_PROJECT", "proj")
+	os.Unsetenv("PATHTYPE_UNDEFINED")
+
+	lookup := func(key string) (string, bool) {
+		if key == "DIR" {
+			return "/custom", true
+		}
+		return "", false
+	}
+	res, err := path("$DIR/${X}").ExpandEnvWith(pt.ExpandOptions{Lookup: lookup, Strict: true})
+	var undefined *pt.UndefinedVarError
+	ok := errors.As(err, &undefined)
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect("~/data/proj/cache", "a//b", "$DIR/${X}", true, "X")
+	t1.Result(path("~/data/$PATHTYPE_PROJECT/cache").ExpandEnv(), path("a/$PATHTYPE_UNDEFINED/b").ExpandEnv(),
+		res, ok, undefined.Name)
+	t1.AssertEquals()
+
+	t1 = tester{TB: t, Transform: pathToString}
+	t1.Expect("/custom/", nil, "proj/", nil)
+	res1, err1 := path("$DIR/${X}").ExpandEnvWith(pt.ExpandOptions{Lookup: lookup})
+	res2, err2 := path("${PATHTYPE_PROJECT}/$PATHTYPE_UNDEFINED").ExpandEnvWith(pt.ExpandOptions{})
+	t1.Result(res1, err1, res2, err2)
+	t1.AssertEquals()
+}
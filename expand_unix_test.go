@@ -0,0 +1,67 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package pathtype_test
+
+import (
+	"os/user"
+	"testing"
+)
+
+func TestExpandUser(t *testing.T) {
+	setenv(t, "HOME", "/home/me")
+	u, err := user.Current()
+	if err != nil {
+		t.Skip("no current user:", err)
+	}
+
+	tests := []struct {
+		path   path
+		expect string
+	}{
+		{"~", "/home/me"},
+		{"~/", "/home/me/"},
+		{"~/data/x", "/home/me/data/x"},
+		{path("~" + u.Username + "/x"), u.HomeDir + "/x"},
+		{"a/~/b", "a/~/b"},
+		{"/abs", "/abs"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.expect, nil)
+		t1.Result(test.path.ExpandUser())
+		t1.AssertEquals()
+	}
+
+	_, err = path("~no-such-user-pathtype/x").ExpandUser()
+	if err == nil {
+		t.Error("ExpandUser with an unknown user: expected an error")
+	}
+}
+
+func TestContractUser(t *testing.T) {
+	setenv(t, "HOME", "/home/me/")
+	tests := []struct {
+		path   path
+		expect string
+	}{
+		{"/home/me", "~"},
+		{"/home/me/data/x", "~/data/x"},
+		{"/home/meta", "/home/meta"},
+		{"/home", "/home"},
+		{"data", "data"},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.expect)
+		t1.Result(test.path.ContractUser())
+		t1.AssertEquals()
+	}
+
+	setenv(t, "HOME", "/")
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect("/data")
+	t1.Result(path("/data").ContractUser())
+	t1.AssertEquals()
+}
@@ -0,0 +1,9 @@
+package pathtype
+
+// SetRename replaces the function Move uses to rename files, and returns a
+// function restoring the original.
+func SetRename(f func(oldpath, newpath string) error) (restore func()) {
+	orig := rename
+	rename = f
+	return func() { rename = orig }
+}
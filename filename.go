@@ -0,0 +1,117 @@
+package pathtype
+
+import (
+	"os"
+	"strings"
+)
+
+// The methods in this file treat the last element of a path, its name, as
+// a stem followed by extensions. Unlike Ext, they do not count the leading
+// dots of a name, so that ".bashrc" has no extension, nor a trailing dot,
+// so that "file." has none either. A path that has no name, because it is
+// empty, a root, or ends in "." or "..", has no stem or extensions, and the
+// methods that replace part of the name return it unchanged.
+
+// Stem returns the name of path without its last extension: "report.tar"
+// for "dir/report.tar.gz", and ".bashrc" for ".bashrc".
+func (path Path) Stem() string {
+	_, name := path.splitName()
+	_, exts := splitExts(name)
+	if len(exts) == 0 {
+		return name
+	}
+	return name[:len(name)-len(exts[len(exts)-1])]
+}
+
+// Exts returns the extensions of the name of path, in order: ".tar" and
+// ".gz" for "dir/report.tar.gz". It returns nil if there are none.
+func (path Path) Exts() []string {
+	_, name := path.splitName()
+	_, exts := splitExts(name)
+	return exts
+}
+
+// TrimExt returns path without any of the extensions of its name:
+// "dir/report" for "dir/report.tar.gz". path.TrimExt().WithExt(".zip")
+// turns it into "dir/report.zip".
+func (path Path) TrimExt() Path {
+	dir, name := path.splitName()
+	if name == "" {
+		return path
+	}
+	base, _ := splitExts(name)
+	return dir + Path(base)
+}
+
+// WithExt returns path with the last extension of its name replaced by
+// ext, or with ext added if it has none: "dir/report.tar.zip" for
+// "dir/report.tar.gz" and ".zip". A leading dot is added to ext if it
+// lacks one; if ext is empty, the last extension is removed.
+func (path Path) WithExt(ext string) Path {
+	dir, name := path.splitName()
+	if name == "" {
+		return path
+	}
+	if ext != "" && ext[0] != '.' {
+		ext = "." + ext
+	}
+	return dir + Path(path.Stem()+ext)
+}
+
+// WithStem returns path with the stem of its name replaced by stem,
+// keeping the last extension: "dir/summary.gz" for "dir/report.tar.gz"
+// and "summary".
+func (path Path) WithStem(stem string) Path {
+	dir, name := path.splitName()
+	if name == "" {
+		return path
+	}
+	_, exts := splitExts(name)
+	if len(exts) > 0 {
+		stem += exts[len(exts)-1]
+	}
+	return dir + Path(stem)
+}
+
+// WithName returns path with its name replaced by name: "dir/b.txt" for
+// "dir/a.txt" and "b.txt". Trailing separators are dropped.
+func (path Path) WithName(name Path) Path {
+	dir, old := path.splitName()
+	if old == "" {
+		return path
+	}
+	return dir + name
+}
+
+// splitName splits path into its name and everything before it, ignoring
+// trailing separators. The name is empty if path has none.
+func (path Path) splitName() (dir Path, name string) {
+	vol := len(path.VolumeName())
+	end := len(path)
+	for end > vol+1 && os.IsPathSeparator(path[end-1]) {
+		end--
+	}
+	i := end
+	for i > vol && !os.IsPathSeparator(path[i-1]) {
+		i--
+	}
+	name = string(path[i:end])
+	if name == "." || name == ".." {
+		return path, ""
+	}
+	return path[:i], name
+}
+
+// splitExts splits name into the part before its first extension and its
+// extensions.
+func splitExts(name string) (base string, exts []string) {
+	trimmed := strings.TrimLeft(name, ".")
+	if trimmed == "" || strings.HasSuffix(name, ".") {
+		return name, nil
+	}
+	parts := strings.Split(trimmed, ".")
+	for _, p := range parts[1:] {
+		exts = append(exts, "."+p)
+	}
+	return name[:len(name)-len(trimmed)] + parts[0], exts
+}
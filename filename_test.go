@@ -0,0 +1,65 @@
+package pathtype_test
+
+import (
+	"testing"
+)
+
+func TestStemExts(t *testing.T) {
+	tests := []struct {
+		path    path
+		stem    string
+		exts    []string
+		trimExt string
+	}{
+		{"dir/report.tar.gz", "report.tar", []string{".tar", ".gz"}, "dir/report"},
+		{"report.txt", "report", []string{".txt"}, "report"},
+		{"/a.b/c", "c", nil, "/a.b/c"},
+		{".bashrc", ".bashrc", nil, ".bashrc"},
+		{"dir/.config.json", ".config", []string{".json"}, "dir/.config"},
+		{"file.", "file.", nil, "file."},
+		{"dir/archive.tar.gz/", "archive.tar", []string{".tar", ".gz"}, "dir/archive"},
+		{"..", "", nil, ".."},
+		{"a/.", "", nil, "a/."},
+		{"/", "", nil, "/"},
+		{"", "", nil, ""},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.stem, test.exts, test.trimExt)
+		t1.Result(test.path.Stem(), test.path.Exts(), test.path.TrimExt())
+		t1.AssertEquals()
+	}
+}
+
+func TestWithExt(t *testing.T) {
+	tests := []struct {
+		path     path
+		ext      string
+		withExt  string
+		stem     string
+		withStem string
+		name     path
+		withName string
+	}{
+		{"dir/report.tar.gz", ".zip", "dir/report.tar.zip", "summary", "dir/summary.gz", "x.txt", "dir/x.txt"},
+		{"report", "zip", "report.zip", "summary", "summary", "x", "x"},
+		{"report.txt", "", "report", "r", "r.txt", "y", "y"},
+		{".bashrc", ".bak", ".bashrc.bak", "profile", "profile", ".zshrc", ".zshrc"},
+		{"file.", ".txt", "file..txt", "f", "f", "g", "g"},
+		{"/dir/sub/", ".d", "/dir/sub.d", "other", "/dir/other", "x", "/dir/x"},
+		{"/", ".txt", "/", "x", "/", "x", "/"},
+		{"a/..", ".txt", "a/..", "x", "a/..", "x", "a/.."},
+		{"", ".txt", "", "x", "", "x", ""},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.withExt, test.withStem, test.withName)
+		t1.Result(test.path.WithExt(test.ext), test.path.WithStem(test.stem), test.path.WithName(test.name))
+		t1.AssertEquals()
+	}
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect("dir/report.zip")
+	t1.Result(path("dir/report.tar.gz").TrimExt().WithExt(".zip"))
+	t1.AssertEquals()
+}
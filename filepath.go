@@ -90,10 +90,41 @@ func (path Path) FromSlash() Path {
 // as in Match. The pattern may describe hierarchical names such as
 // /usr/*/bin/ed (assuming the Separator is '/').
 //
+// Brace expressions in pattern are expanded as described in Match and
+// the matches of every resulting pattern are returned in order, without
+// duplicates. If any element of pattern is "**", Glob behaves like GlobAll.
+//
 // Glob ignores file system errors such as I/O errors reading directories.
 // The only possible returned error is ErrBadPattern, when pattern
 // is malformed.
 func (path Path) Glob(pattern string) (matches []Path, err error) {
+	patterns, err := expandOSBraces(pattern)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[Path]bool)
+	for _, p := range patterns {
+		var m []Path
+		if hasGlobStar(p) {
+			m, err = path.globAll(p)
+		} else {
+			m, err = path.glob(p)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m {
+			if !seen[e] {
+				seen[e] = true
+				matches = append(matches, e)
+			}
+		}
+	}
+	return
+}
+
+// glob is Glob without brace expansion or support for "**".
+func (path Path) glob(pattern string) (matches []Path, err error) {
 	p1 := filepath.Join(string(path), pattern)
 	m, err := filepath.Glob(p1)
 	if err != nil {
@@ -144,15 +175,37 @@ func (path Path) Join(elem ...Path) Path {
 //		'\\' c      matches character c
 //		lo '-' hi   matches character c for lo <= c <= hi
 //
+// Before matching, brace expressions in pattern are expanded:
+//
+//	'{' alternative { ',' alternative } '}'
+//	            matches any of the comma separated alternatives,
+//	            which may themselves contain brace expressions
+//	'{' lo '..' hi [ '..' step ] '}'
+//	            matches the integers from lo to hi, zero-padded
+//	            if either bound has a leading zero
+//
+// Braces that hold neither a comma nor a range, such as "{}", are
+// matched literally. Braces inside a character class or escaped with
+// '\\' are not expanded.
+//
 // Match requires pattern to match all of name, not just a substring.
 // The only possible returned error is ErrBadPattern, when pattern
-// is malformed.
+// is malformed or contains an unmatched brace.
 //
 // On Windows, escaping is disabled. Instead, '\\' is treated as
 // path separator.
 //
 func (path Path) Match(pattern string) (bool, error) {
-	return filepath.Match(pattern, string(path))
+	patterns, err := expandOSBraces(pattern)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range patterns {
+		if ok, err := filepath.Match(p, string(path)); ok || err != nil {
+			return ok, err
+		}
+	}
+	return false, nil
 }
 
 // Rel returns a relative path that is lexically equivalent to targpath when
@@ -0,0 +1,459 @@
+package pathtype
+
+import (
+	"errors"
+	"strings"
+)
+
+// The lexical functions in this file are ported from the standard library's
+// path/filepath, Copyright 2009 The Go Authors, under its BSD-style license.
+// Each works for either flavor of path, so that WindowsPath and PosixPath
+// behave exactly as Path does on Windows and on Unix, whatever the OS.
+
+// flavor is the syntax of a family of paths.
+type flavor struct {
+	windows bool
+}
+
+var (
+	windowsFlavor = flavor{windows: true}
+	posixFlavor   = flavor{windows: false}
+)
+
+func (f flavor) separator() byte {
+	if f.windows {
+		return '\\'
+	}
+	return '/'
+}
+
+func (f flavor) isSeparator(c byte) bool {
+	if f.windows {
+		return isWindowsSeparator(c)
+	}
+	return c == '/'
+}
+
+func isWindowsSeparator(c byte) bool {
+	return c == '\\' || c == '/'
+}
+
+// fromSlash replaces each slash in path with the separator of f.
+func (f flavor) fromSlash(path string) string {
+	if !f.windows {
+		return path
+	}
+	return strings.ReplaceAll(path, "/", `\`)
+}
+
+// volumeNameLen returns the length of the leading volume name of path.
+func (f flavor) volumeNameLen(path string) int {
+	if !f.windows {
+		return 0
+	}
+	switch {
+	case len(path) >= 2 && path[1] == ':':
+		// A drive letter.
+		return 2
+	case len(path) == 0 || !isWindowsSeparator(path[0]):
+		return 0
+	case pathHasPrefixFold(path, `\\.`) || pathHasPrefixFold(path, `\\?`) || pathHasPrefixFold(path, `\??`):
+		// A device path: \\.\ for Local Device paths, \\?\ or \??\ for
+		// Root Local Device paths.
+		switch {
+		case len(path) == 3:
+			return 3
+		case pathHasPrefixFold(path[4:], `UNC`):
+			return validVolumeNameLen(path, uncLen(path, len(`\\.\UNC\`)))
+		}
+		// The component after the device prefix is part of the volume
+		// name, so that Clean(`\\?\c:\`) keeps the trailing separator.
+		_, rest, ok := cutPath(path[4:])
+		if !ok {
+			return validVolumeNameLen(path, len(path))
+		}
+		return validVolumeNameLen(path, len(path)-len(rest)-1)
+	case len(path) >= 2 && isWindowsSeparator(path[1]):
+		// A UNC path, \\host\share.
+		return validVolumeNameLen(path, uncLen(path, 2))
+	}
+	return 0
+}
+
+// validVolumeNameLen returns n if path[:n] is a valid Windows volume name,
+// and 0 if it has a ".." element.
+func validVolumeNameLen(path string, n int) int {
+	for p := path[:n]; p != ""; {
+		var part string
+		part, p, _ = cutPath(p)
+		if part == ".." {
+			return 0
+		}
+	}
+	return n
+}
+
+// pathHasPrefixFold reports whether the Windows path s begins with prefix,
+// ignoring case and treating both separators as equivalent. If s is longer
+// than prefix, s[len(prefix)] must be a separator.
+func pathHasPrefixFold(s, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if isWindowsSeparator(prefix[i]) {
+			if !isWindowsSeparator(s[i]) {
+				return false
+			}
+		} else if toUpper(prefix[i]) != toUpper(s[i]) {
+			return false
+		}
+	}
+	return len(s) == len(prefix) || isWindowsSeparator(s[len(prefix)])
+}
+
+func toUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - ('a' - 'A')
+	}
+	return c
+}
+
+// uncLen returns the length of the volume name of a UNC path, where
+// prefixLen is the length of the prefix before the host.
+func uncLen(path string, prefixLen int) int {
+	count := 0
+	for i := prefixLen; i < len(path); i++ {
+		if isWindowsSeparator(path[i]) {
+			if count++; count == 2 {
+				return i
+			}
+		}
+	}
+	return len(path)
+}
+
+// cutPath slices the Windows path around its first separator.
+func cutPath(path string) (before, after string, found bool) {
+	for i := 0; i < len(path); i++ {
+		if isWindowsSeparator(path[i]) {
+			return path[:i], path[i+1:], true
+		}
+	}
+	return path, "", false
+}
+
+func (f flavor) volumeName(path string) string {
+	return f.fromSlash(path[:f.volumeNameLen(path)])
+}
+
+func (f flavor) isAbs(path string) bool {
+	if !f.windows {
+		return strings.HasPrefix(path, "/")
+	}
+	l := f.volumeNameLen(path)
+	if l == 0 {
+		return false
+	}
+	// A volume name that starts with two separators is absolute on its own.
+	if isWindowsSeparator(path[0]) && isWindowsSeparator(path[1]) {
+		return true
+	}
+	path = path[l:]
+	return path != "" && isWindowsSeparator(path[0])
+}
+
+// lazybuf is a path buffer that is only allocated once the output
+// diverges from the input path.
+type lazybuf struct {
+	path       string
+	buf        []byte
+	w          int
+	volAndPath string
+	volLen     int
+}
+
+func (b *lazybuf) index(i int) byte {
+	if b.buf != nil {
+		return b.buf[i]
+	}
+	return b.path[i]
+}
+
+func (b *lazybuf) append(c byte) {
+	if b.buf == nil {
+		if b.w < len(b.path) && b.path[b.w] == c {
+			b.w++
+			return
+		}
+		b.buf = make([]byte, len(b.path))
+		copy(b.buf, b.path[:b.w])
+	}
+	b.buf[b.w] = c
+	b.w++
+}
+
+func (b *lazybuf) prepend(prefix ...byte) {
+	b.buf = append(prefix, b.buf...)
+	b.w += len(prefix)
+}
+
+func (b *lazybuf) string() string {
+	if b.buf == nil {
+		return b.volAndPath[:b.volLen+b.w]
+	}
+	return b.volAndPath[:b.volLen] + string(b.buf[:b.w])
+}
+
+func (f flavor) clean(path string) string {
+	originalPath := path
+	volLen := f.volumeNameLen(path)
+	path = path[volLen:]
+	if path == "" {
+		if volLen > 1 && f.isSeparator(originalPath[0]) && f.isSeparator(originalPath[1]) {
+			// A UNC volume name.
+			return f.fromSlash(originalPath)
+		}
+		return originalPath + "."
+	}
+	rooted := f.isSeparator(path[0])
+
+	// r is the index of the next byte to read from path, out.w the index
+	// of the next byte to write, and dotdot the index in out where ".."
+	// must stop, at the leading separator or after leading ".." elements.
+	n := len(path)
+	out := lazybuf{path: path, volAndPath: originalPath, volLen: volLen}
+	r, dotdot := 0, 0
+	if rooted {
+		out.append(f.separator())
+		r, dotdot = 1, 1
+	}
+
+	for r < n {
+		switch {
+		case f.isSeparator(path[r]):
+			r++
+		case path[r] == '.' && (r+1 == n || f.isSeparator(path[r+1])):
+			r++
+		case path[r] == '.' && path[r+1] == '.' && (r+2 == n || f.isSeparator(path[r+2])):
+			r += 2
+			switch {
+			case out.w > dotdot:
+				out.w--
+				for out.w > dotdot && !f.isSeparator(out.index(out.w)) {
+					out.w--
+				}
+			case !rooted:
+				if out.w > 0 {
+					out.append(f.separator())
+				}
+				out.append('.')
+				out.append('.')
+				dotdot = out.w
+			}
+		default:
+			if rooted && out.w != 1 || !rooted && out.w != 0 {
+				out.append(f.separator())
+			}
+			for ; r < n && !f.isSeparator(path[r]); r++ {
+				out.append(path[r])
+			}
+		}
+	}
+
+	if out.w == 0 {
+		out.append('.')
+	}
+	if f.windows {
+		postClean(&out)
+	}
+	return f.fromSlash(out.string())
+}
+
+// postClean keeps Clean from turning a relative Windows path into an
+// absolute or rooted one.
+func postClean(out *lazybuf) {
+	if out.volLen != 0 || out.buf == nil {
+		return
+	}
+	// A colon in the first element would make it a drive letter, as in
+	// a/../c:, so prefix the path with .\ instead.
+	for _, c := range out.buf {
+		if isWindowsSeparator(c) {
+			break
+		}
+		if c == ':' {
+			out.prepend('.', '\\')
+			return
+		}
+	}
+	// A leading \??\ would make it a Root Local Device path, as in
+	// \a\..\??\c:\x, so prefix the path with \. instead.
+	if len(out.buf) >= 3 && isWindowsSeparator(out.buf[0]) && out.buf[1] == '?' && out.buf[2] == '?' {
+		out.prepend('\\', '.')
+	}
+}
+
+func (f flavor) join(elem []string) string {
+	if !f.windows {
+		for i, e := range elem {
+			if e != "" {
+				return f.clean(strings.Join(elem[i:], "/"))
+			}
+		}
+		return ""
+	}
+	var b strings.Builder
+	var lastChar byte
+	for _, e := range elem {
+		switch {
+		case b.Len() == 0:
+			// The first non-empty element is added unchanged.
+		case isWindowsSeparator(lastChar):
+			// Strip leading separators from the element, so that non-UNC
+			// elements are not joined into a UNC path.
+			for len(e) > 0 && isWindowsSeparator(e[0]) {
+				e = e[1:]
+			}
+			// Join(`\`, `??`) is \.\?? rather than a Root Local Device path.
+			if b.Len() == 1 && strings.HasPrefix(e, "??") && (len(e) == len("??") || isWindowsSeparator(e[2])) {
+				b.WriteString(`.\`)
+			}
+		case lastChar == ':':
+			// A path ending in a colon stays relative to the current
+			// directory of its drive: Join(`C:`, `f`) is C:f.
+		default:
+			b.WriteByte('\\')
+			lastChar = '\\'
+		}
+		if len(e) > 0 {
+			b.WriteString(e)
+			lastChar = e[len(e)-1]
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return f.clean(b.String())
+}
+
+func (f flavor) split(path string) (dir, file string) {
+	vol := f.volumeName(path)
+	i := len(path) - 1
+	for i >= len(vol) && !f.isSeparator(path[i]) {
+		i--
+	}
+	return path[:i+1], path[i+1:]
+}
+
+func (f flavor) base(path string) string {
+	if path == "" {
+		return "."
+	}
+	for len(path) > 0 && f.isSeparator(path[len(path)-1]) {
+		path = path[:len(path)-1]
+	}
+	path = path[len(f.volumeName(path)):]
+	i := len(path) - 1
+	for i >= 0 && !f.isSeparator(path[i]) {
+		i--
+	}
+	if i >= 0 {
+		path = path[i+1:]
+	}
+	if path == "" {
+		// The path had only separators.
+		return string(f.separator())
+	}
+	return path
+}
+
+func (f flavor) dir(path string) string {
+	vol := f.volumeName(path)
+	i := len(path) - 1
+	for i >= len(vol) && !f.isSeparator(path[i]) {
+		i--
+	}
+	dir := f.clean(path[len(vol) : i+1])
+	if dir == "." && len(vol) > 2 {
+		// A UNC volume name.
+		return vol
+	}
+	return vol + dir
+}
+
+// sameWord reports whether the elements a and b are equal; Windows paths
+// are compared without regard to case.
+func (f flavor) sameWord(a, b string) bool {
+	if f.windows {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+func (f flavor) rel(basePath, targPath string) (string, error) {
+	sep := f.separator()
+	baseVol := f.volumeName(basePath)
+	targVol := f.volumeName(targPath)
+	base := f.clean(basePath)
+	targ := f.clean(targPath)
+	if f.sameWord(targ, base) {
+		return ".", nil
+	}
+	base = base[len(baseVol):]
+	targ = targ[len(targVol):]
+	if base == "." {
+		base = ""
+	} else if base == "" && f.volumeNameLen(baseVol) > 2 {
+		// A UNC base path \\host\share is treated as absolute.
+		base = string(sep)
+	}
+
+	// IsAbs cannot be used, as `\a` and `a` are both relative on Windows.
+	baseSlashed := len(base) > 0 && base[0] == sep
+	targSlashed := len(targ) > 0 && targ[0] == sep
+	if baseSlashed != targSlashed || !f.sameWord(baseVol, targVol) {
+		return "", errors.New("Rel: can't make " + targPath + " relative to " + basePath)
+	}
+	// Position base[b0:bi] and targ[t0:ti] at the first differing elements.
+	bl := len(base)
+	tl := len(targ)
+	var b0, bi, t0, ti int
+	for {
+		for bi < bl && base[bi] != sep {
+			bi++
+		}
+		for ti < tl && targ[ti] != sep {
+			ti++
+		}
+		if !f.sameWord(targ[t0:ti], base[b0:bi]) {
+			break
+		}
+		if bi < bl {
+			bi++
+		}
+		if ti < tl {
+			ti++
+		}
+		b0 = bi
+		t0 = ti
+	}
+	if base[b0:bi] == ".." {
+		return "", errors.New("Rel: can't make " + targPath + " relative to " + basePath)
+	}
+	if b0 != bl {
+		// Go up out of the remaining base elements, then down into targ.
+		var b strings.Builder
+		b.WriteString("..")
+		for i := 0; i < strings.Count(base[b0:bl], string(sep)); i++ {
+			b.WriteByte(sep)
+			b.WriteString("..")
+		}
+		if t0 != tl {
+			b.WriteByte(sep)
+			b.WriteString(targ[t0:])
+		}
+		return f.clean(b.String()), nil
+	}
+	return targ[t0:], nil
+}
@@ -0,0 +1,604 @@
+package pathtype_test
+
+import (
+	"strings"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+// The test vectors in this file are those of the standard library's
+// path/filepath tests, Copyright 2009 The Go Authors. The tests common to
+// all platforms are run against both flavors, converted to Windows paths
+// the way path/filepath does on Windows.
+
+type PathTest struct {
+	path, result string
+}
+
+var cleantests = []PathTest{
+	// Already clean
+	{"abc", "abc"},
+	{"abc/def", "abc/def"},
+	{"a/b/c", "a/b/c"},
+	{".", "."},
+	{"..", ".."},
+	{"../..", "../.."},
+	{"../../abc", "../../abc"},
+	{"/abc", "/abc"},
+	{"/", "/"},
+
+	// Empty is current dir
+	{"", "."},
+
+	// Remove trailing slash
+	{"abc/", "abc"},
+	{"abc/def/", "abc/def"},
+	{"a/b/c/", "a/b/c"},
+	{"./", "."},
+	{"../", ".."},
+	{"../../", "../.."},
+	{"/abc/", "/abc"},
+
+	// Remove doubled slash
+	{"abc//def//ghi", "abc/def/ghi"},
+	{"abc//", "abc"},
+
+	// Remove . elements
+	{"abc/./def", "abc/def"},
+	{"/./abc/def", "/abc/def"},
+	{"abc/.", "abc"},
+
+	// Remove .. elements
+	{"abc/def/ghi/../jkl", "abc/def/jkl"},
+	{"abc/def/../ghi/../jkl", "abc/jkl"},
+	{"abc/def/..", "abc"},
+	{"abc/def/../..", "."},
+	{"/abc/def/../..", "/"},
+	{"abc/def/../../..", ".."},
+	{"/abc/def/../../..", "/"},
+	{"abc/def/../../../ghi/jkl/../../../mno", "../../mno"},
+	{"/../abc", "/abc"},
+	{"a/../b:/../../c", `../c`},
+
+	// Combinations
+	{"abc/./../def", "def"},
+	{"abc//./../def", "def"},
+	{"abc/../../././../def", "../../def"},
+}
+
+var nonwincleantests = []PathTest{
+	// Remove leading doubled slash
+	{"//abc", "/abc"},
+	{"///abc", "/abc"},
+	{"//abc//", "/abc"},
+}
+
+var wincleantests = []PathTest{
+	{`c:`, `c:.`},
+	{`c:\`, `c:\`},
+	{`c:\abc`, `c:\abc`},
+	{`c:abc\..\..\.\.\..\def`, `c:..\..\def`},
+	{`c:\abc\def\..\..`, `c:\`},
+	{`c:\..\abc`, `c:\abc`},
+	{`c:..\abc`, `c:..\abc`},
+	{`c:\b:\..\..\..\d`, `c:\d`},
+	{`\`, `\`},
+	{`/`, `\`},
+	{`\\i\..\c$`, `\c$`},
+	{`\\i\..\i\c$`, `\i\c$`},
+	{`\\i\..\I\c$`, `\I\c$`},
+	{`\\..\..\a`, `\a`},
+	{`//../../a`, `\a`},
+	{`\\host\share\foo\..\bar`, `\\host\share\bar`},
+	{`//host/share/foo/../baz`, `\\host\share\baz`},
+	{`\\host\share\foo\..\..\..\..\bar`, `\\host\share\bar`},
+	{`\\?\UNC\host\share\foo\..\..\..\..\bar`, `\\?\UNC\host\share\bar`},
+	{`\??\UNC\host\share\foo\..\..\..\..\bar`, `\??\UNC\host\share\bar`},
+	{`\\.\C:\a\..\..\..\..\bar`, `\\.\C:\bar`},
+	{`\\.\C:\\\\a`, `\\.\C:\a`},
+	{`\\a\b\..\c`, `\\a\b\c`},
+	{`\\a\b`, `\\a\b`},
+	{`.\c:`, `.\c:`},
+	{`.\c:\foo`, `.\c:\foo`},
+	{`.\c:foo`, `.\c:foo`},
+	{`//abc`, `\\abc`},
+	{`///abc`, `\\\abc`},
+	{`//abc//`, `\\abc\\`},
+	{`\\?\C:\`, `\\?\C:\`},
+	{`\\?\C:\a`, `\\?\C:\a`},
+
+	// Don't allow cleaning to move an element with a colon to the start of the path.
+	{`a/../c:`, `.\c:`},
+	{`a\..\c:`, `.\c:`},
+	{`a/../c:/a`, `.\c:\a`},
+	{`a/../../c:`, `..\c:`},
+	{`foo:bar`, `foo:bar`},
+
+	// Don't allow cleaning to create a Root Local Device path like \??\a.
+	{`/a/../??/a`, `\.\??\a`},
+}
+
+type SplitTest struct {
+	path, dir, file string
+}
+
+var unixsplittests = []SplitTest{
+	{"a/b", "a/", "b"},
+	{"a/b/", "a/b/", ""},
+	{"a/", "a/", ""},
+	{"a", "", "a"},
+	{"/", "/", ""},
+}
+
+var winsplittests = []SplitTest{
+	{`c:`, `c:`, ``},
+	{`c:/`, `c:/`, ``},
+	{`c:/foo`, `c:/`, `foo`},
+	{`c:/foo/bar`, `c:/foo/`, `bar`},
+	{`//host/share`, `//host/share`, ``},
+	{`//host/share/`, `//host/share/`, ``},
+	{`//host/share/foo`, `//host/share/`, `foo`},
+	{`\\host\share`, `\\host\share`, ``},
+	{`\\host\share\`, `\\host\share\`, ``},
+	{`\\host\share\foo`, `\\host\share\`, `foo`},
+}
+
+type JoinTest struct {
+	elem []string
+	path string
+}
+
+var jointests = []JoinTest{
+	// zero parameters
+	{[]string{}, ""},
+
+	// one parameter
+	{[]string{""}, ""},
+	{[]string{"/"}, "/"},
+	{[]string{"a"}, "a"},
+
+	// two parameters
+	{[]string{"a", "b"}, "a/b"},
+	{[]string{"a", ""}, "a"},
+	{[]string{"", "b"}, "b"},
+	{[]string{"/", "a"}, "/a"},
+	{[]string{"/", "a/b"}, "/a/b"},
+	{[]string{"/", ""}, "/"},
+	{[]string{"/a", "b"}, "/a/b"},
+	{[]string{"a", "/b"}, "a/b"},
+	{[]string{"/a", "/b"}, "/a/b"},
+	{[]string{"a/", "b"}, "a/b"},
+	{[]string{"a/", ""}, "a"},
+	{[]string{"", ""}, ""},
+
+	// three parameters
+	{[]string{"/", "a", "b"}, "/a/b"},
+}
+
+var nonwinjointests = []JoinTest{
+	{[]string{"//", "a"}, "/a"},
+}
+
+var winjointests = []JoinTest{
+	{[]string{`directory`, `file`}, `directory\file`},
+	{[]string{`C:\Windows\`, `System32`}, `C:\Windows\System32`},
+	{[]string{`C:\Windows\`, ``}, `C:\Windows`},
+	{[]string{`C:\`, `Windows`}, `C:\Windows`},
+	{[]string{`C:`, `a`}, `C:a`},
+	{[]string{`C:`, `a\b`}, `C:a\b`},
+	{[]string{`C:`, `a`, `b`}, `C:a\b`},
+	{[]string{`C:`, ``, `b`}, `C:b`},
+	{[]string{`C:`, ``, ``, `b`}, `C:b`},
+	{[]string{`C:`, ``}, `C:.`},
+	{[]string{`C:`, ``, ``}, `C:.`},
+	{[]string{`C:`, `\a`}, `C:\a`},
+	{[]string{`C:`, ``, `\a`}, `C:\a`},
+	{[]string{`C:.`, `a`}, `C:a`},
+	{[]string{`C:a`, `b`}, `C:a\b`},
+	{[]string{`C:a`, `b`, `d`}, `C:a\b\d`},
+	{[]string{`\\host\share`, `foo`}, `\\host\share\foo`},
+	{[]string{`\\host\share\foo`}, `\\host\share\foo`},
+	{[]string{`//host/share`, `foo/bar`}, `\\host\share\foo\bar`},
+	{[]string{`\`}, `\`},
+	{[]string{`\`, ``}, `\`},
+	{[]string{`\`, `a`}, `\a`},
+	{[]string{`\\`, `a`}, `\\a`},
+	{[]string{`\`, `a`, `b`}, `\a\b`},
+	{[]string{`\\`, `a`, `b`}, `\\a\b`},
+	{[]string{`\`, `\\a\b`, `c`}, `\a\b\c`},
+	{[]string{`\\a`, `b`, `c`}, `\\a\b\c`},
+	{[]string{`\\a\`, `b`, `c`}, `\\a\b\c`},
+	{[]string{`//`, `a`}, `\\a`},
+	{[]string{`a:\b\c`, `x\..\y:\..\..\z`}, `a:\b\z`},
+	{[]string{`\`, `??\a`}, `\.\??\a`},
+}
+
+var basetests = []PathTest{
+	{"", "."},
+	{".", "."},
+	{"/.", "."},
+	{"/", "/"},
+	{"////", "/"},
+	{"x/", "x"},
+	{"abc", "abc"},
+	{"abc/def", "def"},
+	{"a/b/.x", ".x"},
+	{"a/b/c.", "c."},
+	{"a/b/c.x", "c.x"},
+}
+
+var winbasetests = []PathTest{
+	{`c:\`, `\`},
+	{`c:.`, `.`},
+	{`c:\a\b`, `b`},
+	{`c:a\b`, `b`},
+	{`c:a\b\c`, `c`},
+	{`\\host\share\`, `\`},
+	{`\\host\share\a`, `a`},
+	{`\\host\share\a\b`, `b`},
+}
+
+var dirtests = []PathTest{
+	{"", "."},
+	{".", "."},
+	{"/.", "/"},
+	{"/", "/"},
+	{"/foo", "/"},
+	{"x/", "x"},
+	{"abc", "."},
+	{"abc/def", "abc"},
+	{"a/b/.x", "a/b"},
+	{"a/b/c.", "a/b"},
+	{"a/b/c.x", "a/b"},
+}
+
+var nonwindirtests = []PathTest{
+	{"////", "/"},
+}
+
+var windirtests = []PathTest{
+	{`c:\`, `c:\`},
+	{`c:.`, `c:.`},
+	{`c:\a\b`, `c:\a`},
+	{`c:a\b`, `c:a`},
+	{`c:a\b\c`, `c:a\b`},
+	{`\\host\share`, `\\host\share`},
+	{`\\host\share\`, `\\host\share\`},
+	{`\\host\share\a`, `\\host\share\`},
+	{`\\host\share\a\b`, `\\host\share\a`},
+	{`\\\\`, `\\\\`},
+}
+
+type IsAbsTest struct {
+	path  string
+	isAbs bool
+}
+
+var isabstests = []IsAbsTest{
+	{"", false},
+	{"/", true},
+	{"/usr/bin/gcc", true},
+	{"..", false},
+	{"/a/../bb", true},
+	{".", false},
+	{"./", false},
+	{"lala", false},
+}
+
+var winisabstests = []IsAbsTest{
+	{`C:\`, true},
+	{`c\`, false},
+	{`c::`, false},
+	{`c:`, false},
+	{`/`, false},
+	{`\`, false},
+	{`\Windows`, false},
+	{`c:a\b`, false},
+	{`c:\a\b`, true},
+	{`c:/a/b`, true},
+	{`\\host\share`, true},
+	{`\\host\share\`, true},
+	{`\\host\share\foo`, true},
+	{`//host/share/foo/bar`, true},
+	{`\\..\..\a`, false},
+	{`//../../a`, false},
+	{`\\i\..\c$`, false},
+	{`//?/../x`, false},
+	{`//./../x`, false},
+	{`\\?\a\b\c`, true},
+	{`\??\a\b\c`, true},
+}
+
+type RelTests struct {
+	root, path, want string
+}
+
+var reltests = []RelTests{
+	{"a/b", "a/b", "."},
+	{"a/b/.", "a/b", "."},
+	{"a/b", "a/b/.", "."},
+	{"./a/b", "a/b", "."},
+	{"a/b", "./a/b", "."},
+	{"ab/cd", "ab/cde", "../cde"},
+	{"ab/cd", "ab/c", "../c"},
+	{"a/b", "a/b/c/d", "c/d"},
+	{"a/b", "a/b/../c", "../c"},
+	{"a/b/../c", "a/b", "../b"},
+	{"a/b/c", "a/c/d", "../../c/d"},
+	{"a/b", "c/d", "../../c/d"},
+	{"a/b/c/d", "a/b", "../.."},
+	{"a/b/c/d", "a/b/", "../.."},
+	{"a/b/c/d/", "a/b", "../.."},
+	{"a/b/c/d/", "a/b/", "../.."},
+	{"../../a/b", "../../a/b/c/d", "c/d"},
+	{"/a/b", "/a/b", "."},
+	{"/a/b/.", "/a/b", "."},
+	{"/a/b", "/a/b/.", "."},
+	{"/ab/cd", "/ab/cde", "../cde"},
+	{"/ab/cd", "/ab/c", "../c"},
+	{"/a/b", "/a/b/c/d", "c/d"},
+	{"/a/b", "/a/b/../c", "../c"},
+	{"/a/b/../c", "/a/b", "../b"},
+	{"/a/b/c", "/a/c/d", "../../c/d"},
+	{"/a/b", "/c/d", "../../c/d"},
+	{"/a/b/c/d", "/a/b", "../.."},
+	{"/a/b/c/d", "/a/b/", "../.."},
+	{"/a/b/c/d/", "/a/b", "../.."},
+	{"/a/b/c/d/", "/a/b/", "../.."},
+	{"/../../a/b", "/../../a/b/c/d", "c/d"},
+	{".", "a/b", "a/b"},
+	{".", "..", ".."},
+	{"", "../../.", "../.."},
+
+	// can't do purely lexically
+	{"..", ".", "err"},
+	{"..", "a", "err"},
+	{"../..", "..", "err"},
+	{"a", "/a", "err"},
+	{"/a", "a", "err"},
+}
+
+var winreltests = []RelTests{
+	{`C:a\b\c`, `C:a/b/d`, `..\d`},
+	{`C:\`, `D:\`, `err`},
+	{`C:`, `D:`, `err`},
+	{`C:\Projects`, `c:\projects\src`, `src`},
+	{`C:\Projects`, `c:\projects`, `.`},
+	{`C:\Projects\a\..`, `c:\projects`, `.`},
+	{`\\host\share`, `\\host\share\file.txt`, `file.txt`},
+}
+
+type VolumeNameTest struct {
+	path string
+	vol  string
+}
+
+var volumenametests = []VolumeNameTest{
+	{`c:/foo/bar`, `c:`},
+	{`c:`, `c:`},
+	{`c:\`, `c:`},
+	{`2:`, `2:`},
+	{``, ``},
+	{`\\\host`, `\\\host`},
+	{`\\\host\`, `\\\host`},
+	{`\\\host\share`, `\\\host`},
+	{`\\\host\\share`, `\\\host`},
+	{`\\host`, `\\host`},
+	{`//host`, `\\host`},
+	{`\\host\`, `\\host\`},
+	{`//host/`, `\\host\`},
+	{`\\host\share`, `\\host\share`},
+	{`//host/share`, `\\host\share`},
+	{`\\host\share\`, `\\host\share`},
+	{`//host/share/`, `\\host\share`},
+	{`\\host\share\foo`, `\\host\share`},
+	{`//host/share/foo`, `\\host\share`},
+	{`\\host\share\\foo\\\bar\\\\baz`, `\\host\share`},
+	{`//host/share//foo///bar////baz`, `\\host\share`},
+	{`\\host\share\foo\..\bar`, `\\host\share`},
+	{`//host/share/foo/../bar`, `\\host\share`},
+	{`\\..\..\a`, ``},
+	{`//../../a`, ``},
+	{`\\i\..\c$`, ``},
+	{`//./UNC/../share`, ``},
+	{`//?/../x`, ``},
+	{`//./../x`, ``},
+	{`//.../share`, `\\...\share`},
+	{`//host/...`, `\\host\...`},
+	{`//?/..x`, `\\?\..x`},
+	{`//.`, `\\.`},
+	{`//./`, `\\.\`},
+	{`//./NUL`, `\\.\NUL`},
+	{`//?`, `\\?`},
+	{`//?/`, `\\?\`},
+	{`//?/NUL`, `\\?\NUL`},
+	{`/??`, `\??`},
+	{`/??/`, `\??\`},
+	{`/??/NUL`, `\??\NUL`},
+	{`//./a/b`, `\\.\a`},
+	{`//./C:`, `\\.\C:`},
+	{`//./C:/`, `\\.\C:`},
+	{`//./C:/a/b/c`, `\\.\C:`},
+	{`//./UNC/host/share/a/b/c`, `\\.\UNC\host\share`},
+	{`//?/UNC/host/share/a/b/c`, `\\?\UNC\host\share`},
+	{`/??/UNC/host/share/a/b/c`, `\??\UNC\host\share`},
+	{`//./UNC/host`, `\\.\UNC\host`},
+	{`//./UNC/host\`, `\\.\UNC\host\`},
+	{`//./UNC`, `\\.\UNC`},
+	{`//./UNC/`, `\\.\UNC\`},
+	{`\\?\x`, `\\?\x`},
+	{`\??\x`, `\??\x`},
+}
+
+// winPath converts the result of a test common to all platforms to the
+// Windows flavor.
+func winPath(s string) string {
+	return strings.ReplaceAll(s, "/", `\`)
+}
+
+func TestFlavorClean(t *testing.T) {
+	for _, test := range append(append([]PathTest{}, cleantests...), nonwincleantests...) {
+		if s := pt.PosixPath(test.path).Clean(); string(s) != test.result {
+			t.Errorf("PosixPath(%q).Clean() = %q, want %q", test.path, s, test.result)
+		}
+		if s := pt.PosixPath(test.result).Clean(); string(s) != test.result {
+			t.Errorf("PosixPath(%q).Clean() = %q, want %q", test.result, s, test.result)
+		}
+	}
+
+	tests := append([]PathTest{}, cleantests...)
+	for i := range tests {
+		tests[i].result = winPath(tests[i].result)
+	}
+	for _, test := range append(tests, wincleantests...) {
+		if s := pt.WindowsPath(test.path).Clean(); string(s) != test.result {
+			t.Errorf("WindowsPath(%q).Clean() = %q, want %q", test.path, s, test.result)
+		}
+		if s := pt.WindowsPath(test.result).Clean(); string(s) != test.result {
+			t.Errorf("WindowsPath(%q).Clean() = %q, want %q", test.result, s, test.result)
+		}
+	}
+}
+
+func TestFlavorSplit(t *testing.T) {
+	for _, test := range unixsplittests {
+		if d, f := pt.PosixPath(test.path).Split(); string(d) != test.dir || string(f) != test.file {
+			t.Errorf("PosixPath(%q).Split() = %q, %q, want %q, %q", test.path, d, f, test.dir, test.file)
+		}
+	}
+	for _, test := range append(append([]SplitTest{}, unixsplittests...), winsplittests...) {
+		if d, f := pt.WindowsPath(test.path).Split(); string(d) != test.dir || string(f) != test.file {
+			t.Errorf("WindowsPath(%q).Split() = %q, %q, want %q, %q", test.path, d, f, test.dir, test.file)
+		}
+	}
+}
+
+func TestFlavorJoin(t *testing.T) {
+	for _, test := range append(append([]JoinTest{}, jointests...), nonwinjointests...) {
+		var p pt.PosixPath
+		if len(test.elem) > 0 {
+			elem := make([]pt.PosixPath, len(test.elem)-1)
+			for i, e := range test.elem[1:] {
+				elem[i] = pt.PosixPath(e)
+			}
+			p = pt.PosixPath(test.elem[0]).Join(elem...)
+		}
+		if string(p) != test.path {
+			t.Errorf("PosixPath join(%q) = %q, want %q", test.elem, p, test.path)
+		}
+	}
+
+	tests := append([]JoinTest{}, jointests...)
+	for i := range tests {
+		tests[i].path = winPath(tests[i].path)
+	}
+	for _, test := range append(tests, winjointests...) {
+		var p pt.WindowsPath
+		if len(test.elem) > 0 {
+			elem := make([]pt.WindowsPath, len(test.elem)-1)
+			for i, e := range test.elem[1:] {
+				elem[i] = pt.WindowsPath(e)
+			}
+			p = pt.WindowsPath(test.elem[0]).Join(elem...)
+		}
+		if string(p) != test.path {
+			t.Errorf("WindowsPath join(%q) = %q, want %q", test.elem, p, test.path)
+		}
+	}
+}
+
+func TestFlavorBase(t *testing.T) {
+	for _, test := range basetests {
+		if s := pt.PosixPath(test.path).Base(); string(s) != test.result {
+			t.Errorf("PosixPath(%q).Base() = %q, want %q", test.path, s, test.result)
+		}
+	}
+
+	tests := append([]PathTest{}, basetests...)
+	for i := range tests {
+		tests[i].result = string(pt.WindowsPath(tests[i].result).Clean())
+	}
+	for _, test := range append(tests, winbasetests...) {
+		if s := pt.WindowsPath(test.path).Base(); string(s) != test.result {
+			t.Errorf("WindowsPath(%q).Base() = %q, want %q", test.path, s, test.result)
+		}
+	}
+}
+
+func TestFlavorDir(t *testing.T) {
+	for _, test := range append(append([]PathTest{}, dirtests...), nonwindirtests...) {
+		if s := pt.PosixPath(test.path).Dir(); string(s) != test.result {
+			t.Errorf("PosixPath(%q).Dir() = %q, want %q", test.path, s, test.result)
+		}
+	}
+
+	tests := append([]PathTest{}, dirtests...)
+	for i := range tests {
+		tests[i].result = string(pt.WindowsPath(tests[i].result).Clean())
+	}
+	for _, test := range append(tests, windirtests...) {
+		if s := pt.WindowsPath(test.path).Dir(); string(s) != test.result {
+			t.Errorf("WindowsPath(%q).Dir() = %q, want %q", test.path, s, test.result)
+		}
+	}
+}
+
+func TestFlavorIsAbs(t *testing.T) {
+	for _, test := range isabstests {
+		if r := pt.PosixPath(test.path).IsAbs(); r != test.isAbs {
+			t.Errorf("PosixPath(%q).IsAbs() = %v, want %v", test.path, r, test.isAbs)
+		}
+	}
+
+	tests := append([]IsAbsTest{}, winisabstests...)
+	for _, test := range isabstests {
+		// Without a volume name, none of the common tests is absolute; with
+		// one, they are as on Unix.
+		tests = append(tests, IsAbsTest{test.path, false}, IsAbsTest{"c:" + test.path, test.isAbs})
+	}
+	for _, test := range tests {
+		if r := pt.WindowsPath(test.path).IsAbs(); r != test.isAbs {
+			t.Errorf("WindowsPath(%q).IsAbs() = %v, want %v", test.path, r, test.isAbs)
+		}
+	}
+}
+
+func TestFlavorRel(t *testing.T) {
+	for _, test := range reltests {
+		got, err := pt.PosixPath(test.root).Rel(pt.PosixPath(test.path))
+		if test.want == "err" {
+			if err == nil {
+				t.Errorf("PosixPath(%q).Rel(%q) = %q, want error", test.root, test.path, got)
+			}
+		} else if string(got) != test.want || err != nil {
+			t.Errorf("PosixPath(%q).Rel(%q) = %q, %v, want %q", test.root, test.path, got, err, test.want)
+		}
+	}
+
+	tests := append([]RelTests{}, reltests...)
+	for i := range tests {
+		tests[i].want = winPath(tests[i].want)
+	}
+	for _, test := range append(tests, winreltests...) {
+		got, err := pt.WindowsPath(test.root).Rel(pt.WindowsPath(test.path))
+		if test.want == "err" {
+			if err == nil {
+				t.Errorf("WindowsPath(%q).Rel(%q) = %q, want error", test.root, test.path, got)
+			}
+		} else if string(got) != test.want || err != nil {
+			t.Errorf("WindowsPath(%q).Rel(%q) = %q, %v, want %q", test.root, test.path, got, err, test.want)
+		}
+	}
+}
+
+func TestFlavorVolumeName(t *testing.T) {
+	for _, v := range volumenametests {
+		if vol := pt.WindowsPath(v.path).VolumeName(); string(vol) != v.vol {
+			t.Errorf("WindowsPath(%q).VolumeName() = %q, want %q", v.path, vol, v.vol)
+		}
+		if vol := pt.PosixPath(v.path).VolumeName(); vol != "" {
+			t.Errorf("PosixPath(%q).VolumeName() = %q, want \"\"", v.path, vol)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package pathtype
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FollowOptions configures WalkFollow.
+type FollowOptions struct {
+	// ReportDangling makes WalkFollow visit a symbolic link whose target
+	// does not exist with a *DanglingLink entry and a nil error, instead
+	// of calling fn with the error from os.Stat.
+	ReportDangling bool
+}
+
+// LoopError is the error WalkFollow passes to fn for a directory that is
+// the same directory as one of its ancestors, usually because a symbolic
+// link points back up the tree.
+type LoopError struct {
+	Path     Path // the path that leads back to an ancestor
+	Ancestor Path // the ancestor it leads back to
+}
+
+func (e *LoopError) Error() string {
+	return "walk " + string(e.Path) + ": directory loop back to " + string(e.Ancestor)
+}
+
+// DanglingLink is the fs.DirEntry WalkFollow passes to fn for a symbolic
+// link whose target does not exist, when FollowOptions.ReportDangling is
+// set. The embedded DirEntry describes the link itself.
+type DanglingLink struct {
+	fs.DirEntry
+	Target Path // the destination of the link, as returned by Readlink
+}
+
+// WalkFollow walks the file tree rooted at path like WalkDir, calling fn
+// for each file or directory in the tree, including path, in lexical
+// order. Unlike WalkDir, it follows symbolic links: a link is reported
+// with the fs.DirEntry of its target, and a link to a directory is walked
+// as if it were that directory. The paths passed to fn are the paths
+// through the links, not the paths of their targets.
+//
+// To detect loops, WalkFollow remembers the directories on the way from
+// path to the current directory, identified by device and inode number
+// (as compared by os.SameFile). A directory that is the same as one of
+// them is not walked again; instead fn is called for it with a *LoopError,
+// and the walk continues with the next entry unless fn returns an error.
+// A directory reachable through several links that do not form a loop is
+// walked once for every way of reaching it.
+//
+// A symbolic link whose target cannot be read is reported to fn with the
+// error from os.Stat, unless opts.ReportDangling is set and the target
+// does not exist.
+func (path Path) WalkFollow(opts FollowOptions, fn WalkDirFunc) error {
+	w := &followWalk{opts: opts, fn: fn}
+	info, err := path.Stat()
+	if err != nil {
+		err = w.visitBroken(path, nil, err)
+	} else {
+		err = w.walk(path, statDirEntry{info}, info)
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+// followWalk holds the state of WalkFollow.
+type followWalk struct {
+	opts      FollowOptions
+	fn        WalkDirFunc
+	ancestors []fs.FileInfo
+	paths     []Path
+}
+
+func (w *followWalk) walk(path Path, d fs.DirEntry, info fs.FileInfo) error {
+	if info.IsDir() {
+		for i, a := range w.ancestors {
+			if os.SameFile(a, info) {
+				err := w.fn(path, d, &LoopError{Path: path, Ancestor: w.paths[i]})
+				if err == filepath.SkipDir {
+					err = nil
+				}
+				return err
+			}
+		}
+	}
+	if err := w.fn(path, d, nil); err != nil || !info.IsDir() {
+		if err == filepath.SkipDir && info.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := os.ReadDir(string(path))
+	if err != nil {
+		err = w.fn(path, d, err)
+		if err != nil {
+			if err == filepath.SkipDir {
+				err = nil
+			}
+			return err
+		}
+	}
+
+	w.ancestors = append(w.ancestors, info)
+	w.paths = append(w.paths, path)
+	defer func() {
+		w.ancestors = w.ancestors[:len(w.ancestors)-1]
+		w.paths = w.paths[:len(w.paths)-1]
+	}()
+
+	for _, e := range entries {
+		p := path.Join(Path(e.Name()))
+		var err error
+		switch {
+		case e.Type()&fs.ModeSymlink != 0:
+			if info, err1 := p.Stat(); err1 != nil {
+				err = w.visitBroken(p, e, err1)
+			} else {
+				err = w.walk(p, statDirEntry{info}, info)
+			}
+		case e.IsDir():
+			if info, err1 := e.Info(); err1 != nil {
+				err = w.fn(p, e, err1)
+			} else {
+				err = w.walk(p, e, info)
+			}
+		default:
+			err = w.fn(p, e, nil)
+		}
+		if err != nil {
+			if err == filepath.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// visitBroken calls fn for a path that could not be followed.
+func (w *followWalk) visitBroken(path Path, d fs.DirEntry, err error) error {
+	if w.opts.ReportDangling && errors.Is(err, fs.ErrNotExist) {
+		if info, err1 := path.Lstat(); err1 == nil && info.Mode()&fs.ModeSymlink != 0 {
+			target, _ := path.Readlink()
+			return w.fn(path, &DanglingLink{DirEntry: statDirEntry{info}, Target: target}, nil)
+		}
+	}
+	return w.fn(path, d, err)
+}
@@ -0,0 +1,117 @@
+package pathtype_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func prepareFollowTestTree(t *testing.T) path {
+	t.Helper()
+	tmpDir, err := prepareTestDirTree("dir/to/walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpDir.Join("dir/to/walk/file.txt").WriteFile(nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpDir.Join("other").Mkdir(0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := tmpDir.Join("other/o.txt").WriteFile(nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	links := map[path]path{
+		"dir/to/walk/up":      "../..",
+		"dir/to/walk/other":   "../../../other",
+		"dir/to/walk/file":    "file.txt",
+		"dir/to/walk/missing": "nowhere",
+	}
+	for link, target := range links {
+		if err := target.Symlink(tmpDir.Join(link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+func TestWalkFollow(t *testing.T) {
+	tmpDir := prepareFollowTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	for _, dangling := range []bool{false, true} {
+		var result []string
+		err := tmpDir.Join("dir").WalkFollow(pt.FollowOptions{ReportDangling: dangling}, func(p path, d fs.DirEntry, err error) error {
+			rel, _ := tmpDir.Rel(p)
+			var loop *pt.LoopError
+			switch {
+			case errors.As(err, &loop):
+				ancestor, _ := tmpDir.Rel(loop.Ancestor)
+				result = append(result, fmt.Sprintf("%s loop %s", rel.ToSlash(), ancestor.ToSlash()))
+			case errors.Is(err, fs.ErrNotExist):
+				result = append(result, fmt.Sprintf("%s not exist", rel.ToSlash()))
+			case err != nil:
+				return err
+			default:
+				if l, ok := d.(*pt.DanglingLink); ok {
+					result = append(result, fmt.Sprintf("%s dangling %s", rel.ToSlash(), l.Target))
+				} else {
+					result = append(result, fmt.Sprintf("%s %v", rel.ToSlash(), d.IsDir()))
+				}
+			}
+			return nil
+		})
+		missing := "dir/to/walk/missing not exist"
+		if dangling {
+			missing = "dir/to/walk/missing dangling nowhere"
+		}
+		expect := []string{
+			"dir true",
+			"dir/to true",
+			"dir/to/walk true",
+			"dir/to/walk/file false",
+			"dir/to/walk/file.txt false",
+			missing,
+			"dir/to/walk/other true",
+			"dir/to/walk/other/o.txt false",
+			"dir/to/walk/up loop dir",
+		}
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(expect, nil)
+		t1.Result(result, err)
+		t1.AssertEquals()
+	}
+}
+
+func TestWalkFollowRoot(t *testing.T) {
+	tmpDir := prepareFollowTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	for _, root := range []path{"dir/to/walk/other", "dir/to/walk/missing", "nowhere"} {
+		for _, dangling := range []bool{false, true} {
+			var result []string
+			err := tmpDir.Join(root).WalkFollow(pt.FollowOptions{ReportDangling: dangling}, func(p path, d fs.DirEntry, err error) error {
+				rel, _ := tmpDir.Rel(p)
+				_, isDangling := d.(*pt.DanglingLink)
+				result = append(result, fmt.Sprint(rel.ToSlash(), " ", isDangling, " ", err != nil))
+				return err
+			})
+			var expect []string
+			switch {
+			case root == "dir/to/walk/other":
+				expect = []string{string(root) + " false false", string(root) + "/o.txt false false"}
+			case root == "dir/to/walk/missing" && dangling:
+				expect = []string{string(root) + " true false"}
+			default:
+				expect = []string{string(root) + " false true"}
+			}
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(expect, !(root == "dir/to/walk/other" || dangling && root == "dir/to/walk/missing"))
+			t1.Result(result, err != nil)
+			t1.AssertEquals()
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package pathtype
 
-import "io/fs"
+import (
+	"io/fs"
+	pathpkg "path"
+)
 
 // ValidPath reports whether the given path
 // is valid for use in a call to Open.
@@ -25,10 +28,106 @@ func (path Path) ReadDirFS(fsys fs.FS) ([]fs.DirEntry, error) {
 	return fs.ReadDir(fsys, string(path))
 }
 
+// GlobFS returns the names of all files in FS fsys matching path.Join(pattern)
+// or nil if there is no matching file. The syntax of patterns is the same
+// as in path.Match. The pattern may describe hierarchical names such as
+// usr/*/bin/ed.
+//
+// GlobFS ignores file system errors such as I/O errors reading directories.
+// The only possible returned error is path.ErrBadPattern, reporting that
+// the pattern is malformed.
+//
+// Brace expressions in pattern are expanded as described in Path.Match,
+// with '\\' always acting as an escape character, and the matches of every
+// resulting pattern are returned in order, without duplicates.
+//
+// If fsys implements fs.GlobFS, GlobFS calls fsys.Glob.
+// Otherwise, GlobFS uses fs.ReadDir to traverse the directory tree
+// and look for matches for the pattern.
+func (path Path) GlobFS(fsys fs.FS, pattern string) (matches []Path, err error) {
+	patterns, err := expandBraces(pattern, true)
+	if err != nil {
+		return nil, pathpkg.ErrBadPattern
+	}
+	seen := make(map[Path]bool)
+	for _, p := range patterns {
+		p1 := pathpkg.Join(string(path), p)
+		m, err := fs.Glob(fsys, p1)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m {
+			if !seen[Path(e)] {
+				seen[Path(e)] = true
+				matches = append(matches, Path(e))
+			}
+		}
+	}
+	return
+}
+
+// OpenFS opens the file at path in FS fsys.
+//
+// When Open returns an error, it should be of type *fs.PathError
+// with the Op field set to "open", the Path field set to path,
+// and the Err field describing the problem.
+func (path Path) OpenFS(fsys fs.FS) (fs.File, error) {
+	return fsys.Open(string(path))
+}
+
+// ReadFileFS reads the file at path in FS fsys and returns its contents.
+// A successful call returns a nil error, not io.EOF.
+// (Because ReadFileFS reads the whole file, the expected EOF
+// from the final Read is not treated as an error to be reported.)
+//
+// If fsys implements fs.ReadFileFS, ReadFileFS calls fsys.ReadFile.
+// Otherwise ReadFileFS calls fsys.Open and uses Read and Close
+// on the returned file.
+func (path Path) ReadFileFS(fsys fs.FS) ([]byte, error) {
+	return fs.ReadFile(fsys, string(path))
+}
+
+// StatFS returns a FileInfo describing the file at path in FS fsys.
+//
+// If fsys implements fs.StatFS, StatFS calls fsys.Stat.
+// Otherwise, StatFS opens the file to stat it.
+func (path Path) StatFS(fsys fs.FS) (fs.FileInfo, error) {
+	return fs.Stat(fsys, string(path))
+}
+
 // Sub returns an FS corresponding to the subtree rooted at fsys's directory located at path.
 func (path Path) Sub(fsys fs.FS) (fs.FS, error) {
 	return fs.Sub(fsys, string(path))
 }
 
+// WalkDirFS walks the file tree rooted at path in FS fsys, calling fn for each
+// file or directory in the tree, including path.
+//
+// All errors that arise visiting files and directories are filtered by fn:
+// see the fs.WalkDirFunc documentation for details.
+//
+// The files are walked in lexical order, which makes the output deterministic
+// but requires WalkDirFS to read an entire directory into memory before proceeding
+// to walk that directory.
+//
+// WalkDirFS does not follow symbolic links found in directories,
+// but if path itself names a symbolic link, its target will be walked.
+func (path Path) WalkDirFS(fsys fs.FS, fn WalkDirFunc) error {
+	fn1 := func(p string, d fs.DirEntry, err error) error {
+		return fn(Path(p), d, err)
+	}
+	return fs.WalkDir(fsys, string(path), fn1)
+}
+
 // WalkDirFunc is the type of the function called by WalkDir to visit each file or directory.
 type WalkDirFunc func(path Path, d fs.DirEntry, err error) error
+
+// statDirEntry is an fs.DirEntry built from an fs.FileInfo.
+type statDirEntry struct {
+	info fs.FileInfo
+}
+
+func (d statDirEntry) Name() string               { return d.info.Name() }
+func (d statDirEntry) IsDir() bool                { return d.info.IsDir() }
+func (d statDirEntry) Type() fs.FileMode          { return d.info.Mode().Type() }
+func (d statDirEntry) Info() (fs.FileInfo, error) { return d.info, nil }
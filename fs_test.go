@@ -1,8 +1,11 @@
 package pathtype_test
 
 import (
+	"fmt"
 	"io/fs"
+	pathpkg "path"
 	"testing"
+	"testing/fstest"
 )
 
 func TestValidPath(t *testing.T) {
@@ -31,3 +34,73 @@ func TestSub(t *testing.T) {
 		t1.AssertSimilar()
 	}
 }
+
+func TestWalkDirFS(t *testing.T) {
+	walkFsys := fstest.MapFS{
+		"a/b/c.txt": {Data: []byte("c")},
+		"a/d.txt":   {Data: []byte("d")},
+		"e/skip/f":  {Data: []byte("f")},
+		"e/g.txt":   {Data: []byte("g")},
+		"hello.txt": {Data: []byte("hi")},
+		"empty/dir": {Mode: fs.ModeDir},
+	}
+	for _, p := range append(testPaths, "a", "e", "hello.txt") {
+		var expect, result []string
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(fs.WalkDir(walkFsys, string(p), func(p string, d fs.DirEntry, err error) error {
+			expect = append(expect, fmt.Sprint(p, err))
+			if d != nil && d.IsDir() && d.Name() == "skip" {
+				return fs.SkipDir
+			}
+			return nil
+		}))
+		t1.Result(p.WalkDirFS(walkFsys, func(p path, d fs.DirEntry, err error) error {
+			result = append(result, fmt.Sprint(p, err))
+			if d != nil && d.IsDir() && d.Name() == "skip" {
+				return fs.SkipDir
+			}
+			return nil
+		}))
+		t1.Expect(expect)
+		t1.Result(result)
+		t1.AssertEquals()
+	}
+}
+
+func TestGlobFS(t *testing.T) {
+	for _, p := range testPaths {
+		for _, p1 := range append(testPatterns, "hello*", "*.txt") {
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(fs.Glob(fsys, pathpkg.Join(string(p), p1)))
+			t1.Result(p.GlobFS(fsys, p1))
+			t1.AssertEquals()
+		}
+	}
+}
+
+func TestOpenFS(t *testing.T) {
+	for _, p := range append(testPaths, "hello world.txt") {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(fsys.Open(string(p)))
+		t1.Result(p.OpenFS(fsys))
+		t1.AssertSimilar()
+	}
+}
+
+func TestReadFileFS(t *testing.T) {
+	for _, p := range append(testPaths, "hello world.txt", "hello-world2.txt") {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(fs.ReadFile(fsys, string(p)))
+		t1.Result(p.ReadFileFS(fsys))
+		t1.AssertEquals()
+	}
+}
+
+func TestStatFS(t *testing.T) {
+	for _, p := range append(testPaths, "hello world.txt", "hello-world2.txt") {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(fs.Stat(fsys, string(p)))
+		t1.Result(p.StatFS(fsys))
+		t1.AssertEquals()
+	}
+}
@@ -0,0 +1,190 @@
+package pathtype
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// globStar is the pattern element that matches zero or more path elements.
+const globStar = "**"
+
+// GlobAll returns the names of all files matching path.Join(pattern) or nil
+// if there is no matching file. The syntax of patterns is the same as in
+// Match, with one addition: a pattern element that consists of exactly "**"
+// matches zero or more directories, so "**/*_test.go" matches every file
+// ending in "_test.go" at any depth below path. A "**" that is only part of
+// an element behaves like "*".
+//
+// GlobAll walks the directory tree with WalkDir, starting at the longest
+// leading part of the pattern that contains no special characters, and
+// skips directories that cannot lead to a match. Matches are returned in
+// lexical order. Like Match, "*" and "**" also match names beginning with
+// a dot. Symbolic links are not followed.
+//
+// Brace expressions in pattern are expanded as described in Match and
+// the matches of every resulting pattern are returned in order, without
+// duplicates.
+//
+// GlobAll ignores file system errors such as I/O errors reading directories.
+// The only possible returned error is ErrBadPattern, when pattern
+// is malformed.
+func (path Path) GlobAll(pattern string) (matches []Path, err error) {
+	patterns, err := expandOSBraces(pattern)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[Path]bool)
+	for _, p := range patterns {
+		m, err := path.globAll(p)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range m {
+			if !seen[e] {
+				seen[e] = true
+				matches = append(matches, e)
+			}
+		}
+	}
+	return matches, nil
+}
+
+// globAll is GlobAll without brace expansion.
+func (path Path) globAll(pattern string) (matches []Path, err error) {
+	full := path.Join(Path(pattern))
+	root, pat := splitGlobPattern(full)
+	for _, e := range pat {
+		if _, err = filepath.Match(e, ""); err != nil {
+			return nil, err
+		}
+	}
+	if len(pat) == 0 {
+		if _, err := full.Lstat(); err == nil {
+			matches = append(matches, full)
+		}
+		return matches, nil
+	}
+
+	// WalkDir does not enter a root that is a symbolic link, so walk the
+	// directory it points to, as Glob follows links in the literal prefix,
+	// and report the matches under root.
+	walkRoot := root
+	if info, err := root.Lstat(); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+		if walkRoot, err = root.EvalSymlinks(); err != nil {
+			return nil, nil
+		}
+	}
+	walkRoot.WalkDir(func(p Path, d fs.DirEntry, err error) error {
+		if err != nil || p == walkRoot {
+			return nil
+		}
+		rel, err := walkRoot.Rel(p)
+		if err != nil {
+			return nil
+		}
+		name := strings.Split(string(rel), string(filepath.Separator))
+		if matchElems(pat, name, filepath.Match) {
+			matches = append(matches, root.Join(rel))
+		}
+		if d.IsDir() && !matchElemsPrefix(pat, name, filepath.Match) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return matches, nil
+}
+
+// splitGlobPattern splits the cleaned pattern into the longest leading
+// directory without special characters and the remaining pattern elements.
+func splitGlobPattern(pattern Path) (root Path, elems []string) {
+	vol := pattern.VolumeName()
+	rest := string(pattern[len(vol):])
+	prefix := string(vol)
+	if len(rest) > 0 && os.IsPathSeparator(rest[0]) {
+		prefix += string(filepath.Separator)
+		rest = rest[1:]
+	}
+	if rest != "" {
+		elems = strings.Split(rest, string(filepath.Separator))
+	}
+	i := 0
+	for i < len(elems) && !hasMeta(elems[i]) {
+		i++
+	}
+	root = Path(prefix + strings.Join(elems[:i], string(filepath.Separator)))
+	if root == "" {
+		root = "."
+	}
+	return root, elems[i:]
+}
+
+// hasMeta reports whether pattern contains any of the magic characters
+// recognized by Match.
+func hasMeta(pattern string) bool {
+	magicChars := `*?[`
+	if runtime.GOOS != "windows" {
+		magicChars = `*?[\`
+	}
+	return strings.ContainsAny(pattern, magicChars)
+}
+
+// hasGlobStar reports whether any element of pattern is "**".
+func hasGlobStar(pattern string) bool {
+	for _, e := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if e == globStar {
+			return true
+		}
+	}
+	return false
+}
+
+// matchElems reports whether the path elements in name match the pattern
+// elements in pat, where a "**" pattern element matches zero or more
+// path elements and every other element is matched by match.
+func matchElems(pat, name []string, match func(pattern, name string) (bool, error)) bool {
+	for len(pat) > 0 {
+		if pat[0] == globStar {
+			for len(pat) > 0 && pat[0] == globStar {
+				pat = pat[1:]
+			}
+			if len(pat) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchElems(pat, name[i:], match) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := match(pat[0], name[0]); !ok {
+			return false
+		}
+		pat, name = pat[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// matchElemsPrefix reports whether some path below the directory whose
+// elements are name could match pat.
+func matchElemsPrefix(pat, name []string, match func(pattern, name string) (bool, error)) bool {
+	for len(name) > 0 {
+		if len(pat) == 0 {
+			return false
+		}
+		if pat[0] == globStar {
+			return true
+		}
+		if ok, _ := match(pat[0], name[0]); !ok {
+			return false
+		}
+		pat, name = pat[1:], name[1:]
+	}
+	return len(pat) > 0
+}
@@ -0,0 +1,134 @@
+package pathtype_test
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+var globTestFiles = []path{
+	"a.go",
+	"a_test.go",
+	"dir/b.go",
+	"dir/b_test.go",
+	"dir/to/c_test.go",
+	"dir/to/walk/d.txt",
+	"dir/to/walk/skip/e_test.go",
+	"dir/to/walk/skip/.hidden_test.go",
+	"other/f_test.go",
+}
+
+func prepareGlobTestTree(t *testing.T) path {
+	t.Helper()
+	tmpDir, err := prepareTestDirTree("dir/to/walk/skip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range globTestFiles {
+		p = tmpDir.Join(p)
+		if err := p.Dir().MkdirAll(0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.WriteFile(nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+func TestGlobAll(t *testing.T) {
+	tmpDir := prepareGlobTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	tests := []struct {
+		pattern string
+		expect  []path
+	}{
+		{"**/*_test.go", []path{
+			"a_test.go",
+			"dir/b_test.go",
+			"dir/to/c_test.go",
+			"dir/to/walk/skip/.hidden_test.go",
+			"dir/to/walk/skip/e_test.go",
+			"other/f_test.go",
+		}},
+		{"dir/**/*.go", []path{
+			"dir/b.go",
+			"dir/b_test.go",
+			"dir/to/c_test.go",
+			"dir/to/walk/skip/.hidden_test.go",
+			"dir/to/walk/skip/e_test.go",
+		}},
+		{"**/walk/**", []path{
+			"dir/to/walk",
+			"dir/to/walk/d.txt",
+			"dir/to/walk/skip",
+			"dir/to/walk/skip/.hidden_test.go",
+			"dir/to/walk/skip/e_test.go",
+		}},
+		{"dir/**/skip", []path{"dir/to/walk/skip"}},
+		{"**/?_test.go", []path{
+			"a_test.go",
+			"dir/b_test.go",
+			"dir/to/c_test.go",
+			"dir/to/walk/skip/e_test.go",
+			"other/f_test.go",
+		}},
+		{"dir/to/walk/d.txt", []path{"dir/to/walk/d.txt"}},
+		{"dir/to/walk/missing", nil},
+		{"**/missing", nil},
+		{"missing/**", nil},
+	}
+	for _, test := range tests {
+		var expect []path
+		for _, p := range test.expect {
+			expect = append(expect, tmpDir.Join(p))
+		}
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathToString(expect), nil)
+		t1.Result(tmpDir.GlobAll(test.pattern))
+		t1.AssertEquals()
+
+		t2 := tester{TB: t, Transform: pathToString}
+		t2.Expect(pathToString(expect), nil)
+		t2.Result(tmpDir.Glob(test.pattern))
+		t2.AssertEquals()
+	}
+}
+
+func TestGlobAllSymlinkRoot(t *testing.T) {
+	tmpDir := prepareGlobTestTree(t)
+	defer tmpDir.RemoveAll()
+	if err := path("dir").Symlink(tmpDir.Join("link")); err != nil {
+		t.Skip("cannot create symbolic links:", err)
+	}
+
+	expect := []string{string(tmpDir.Join("link/b.go")), string(tmpDir.Join("link/b_test.go"))}
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(expect, nil, expect, nil, expect, nil)
+	res1, err1 := tmpDir.Join("link").GlobAll("**/b*.go")
+	res2, err2 := tmpDir.GlobAll("link/**/b*.go")
+	res3, err3 := tmpDir.Glob("link/**/b*.go")
+	t1.Result(res1, err1, res2, err2, res3, err3)
+	t1.AssertEquals()
+}
+
+func TestGlobAllWithoutGlobStar(t *testing.T) {
+	tmpDir := prepareGlobTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	for _, p1 := range append(testPatterns, "*.go", "dir/*", "*/*/c_test.go", "dir/to/*/*", "[") {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(filepath.Glob(filepath.Join(string(tmpDir), p1)))
+		t1.Result(tmpDir.GlobAll(p1))
+		t1.AssertEquals()
+	}
+}
+
+func TestGlobAllBadPattern(t *testing.T) {
+	for _, p := range testPaths {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect([]string(nil), filepath.ErrBadPattern)
+		t1.Result(p.GlobAll("**/a[b"))
+		t1.AssertEquals()
+	}
+}
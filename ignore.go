@@ -0,0 +1,192 @@
+package pathtype
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	pathpkg "path"
+	"path/filepath"
+	"strings"
+)
+
+// Ignore matches paths against patterns written in .gitignore syntax.
+//
+// Each pattern belongs to a base directory, usually the directory holding
+// the ignore file it was read from, and only applies to paths below that
+// directory. Patterns are checked from the most recently added to the
+// oldest, so patterns from deeper ignore files and later lines take
+// precedence, as they do in git. The base directories and the paths being
+// matched must either all be relative or all be absolute.
+//
+// The zero value is an empty Ignore ready to use.
+type Ignore struct {
+	// FileName is the name of the per-directory ignore file loaded by
+	// WalkDirFunc. If empty, ".gitignore" is used.
+	FileName string
+
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	base    Path
+	elems   []string
+	negate  bool
+	dirOnly bool
+}
+
+// AddPatterns adds patterns in .gitignore syntax, one per string, that
+// apply to the paths below the directory base:
+//
+//   - Blank lines and lines starting with '#' are ignored.
+//   - Trailing spaces are ignored unless escaped with '\\'.
+//   - A leading '!' negates the pattern, re-including a path excluded
+//     by an earlier pattern. A path cannot be re-included if one of its
+//     parent directories is excluded.
+//   - A trailing '/' makes the pattern match only directories.
+//   - A pattern containing a '/' at the beginning or in the middle is
+//     matched relative to base; otherwise it matches a name at any
+//     depth below base.
+//   - A leading "**/" matches in all directories, a trailing "/**"
+//     matches everything inside, and "/**/" matches zero or more
+//     directories. Other elements use the syntax of path.Match.
+//
+// Patterns that cannot be parsed are skipped, as git does.
+func (ig *Ignore) AddPatterns(base Path, patterns ...string) {
+	for _, p := range patterns {
+		if r, ok := parseIgnoreRule(base, p); ok {
+			ig.rules = append(ig.rules, r)
+		}
+	}
+}
+
+// AddFile reads the ignore file at file and adds its patterns, relative
+// to the directory holding the file.
+func (ig *Ignore) AddFile(file Path) error {
+	data, err := file.ReadFile()
+	if err != nil {
+		return err
+	}
+	var lines []string
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return &fs.PathError{Op: "read", Path: string(file), Err: err}
+	}
+	ig.AddPatterns(file.Dir(), lines...)
+	return nil
+}
+
+// Match reports whether the path p, which is a directory if isDir is true,
+// is ignored, either by a pattern matching it or by a pattern matching one
+// of its parent directories.
+func (ig *Ignore) Match(p Path, isDir bool) bool {
+	p = p.Clean()
+	var parents []Path
+	for dir, prev := p.Dir(), p; dir != prev; dir, prev = dir.Dir(), dir {
+		parents = append(parents, dir)
+	}
+	for i := len(parents) - 1; i >= 0; i-- {
+		if ig.match(parents[i], true) {
+			return true
+		}
+	}
+	return ig.match(p, isDir)
+}
+
+// match reports whether p is ignored by its own patterns, without looking
+// at its parent directories.
+func (ig *Ignore) match(p Path, isDir bool) bool {
+	for i := len(ig.rules) - 1; i >= 0; i-- {
+		r := ig.rules[i]
+		if r.dirOnly && !isDir {
+			continue
+		}
+		rel, err := r.base.Rel(p)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(string(rel), ".."+string(filepath.Separator)) {
+			continue
+		}
+		if matchElems(r.elems, strings.Split(string(rel.ToSlash()), "/"), pathpkg.Match) {
+			return !r.negate
+		}
+	}
+	return false
+}
+
+// WalkDirFunc returns a WalkDirFunc for WalkDir that calls fn for every
+// file and directory that is not ignored, and returns filepath.SkipDir for
+// ignored directories so that their contents are never read.
+//
+// After fn has visited a directory, the ignore file named by FileName in
+// that directory, if any, is loaded and its patterns apply to everything
+// below it. If the ignore file cannot be read, fn is called a second time
+// for the directory with the error, as WalkDir does when a directory cannot
+// be read.
+func (ig *Ignore) WalkDirFunc(fn WalkDirFunc) WalkDirFunc {
+	return func(p Path, d fs.DirEntry, err error) error {
+		if err != nil || d == nil {
+			return fn(p, d, err)
+		}
+		if ig.match(p, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if err := fn(p, d, nil); err != nil || !d.IsDir() {
+			return err
+		}
+		name := ig.FileName
+		if name == "" {
+			name = ".gitignore"
+		}
+		if err := ig.AddFile(p.Join(Path(name))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fn(p, d, err)
+		}
+		return nil
+	}
+}
+
+// parseIgnoreRule parses a single line of an ignore file.
+func parseIgnoreRule(base Path, line string) (r ignoreRule, ok bool) {
+	line = strings.TrimSuffix(line, "\r")
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || line[0] == '#' {
+		return r, false
+	}
+	r.base = base.Clean()
+	if line[0] == '!' {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return r, false
+	}
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	r.elems = strings.Split(line, "/")
+	for _, e := range r.elems {
+		if _, err := pathpkg.Match(e, ""); err != nil {
+			return r, false
+		}
+	}
+	if !anchored {
+		r.elems = append([]string{globStar}, r.elems...)
+	}
+	if r.elems[len(r.elems)-1] == globStar {
+		// A trailing "/**" matches everything inside, but not the
+		// directory itself.
+		r.elems = append(r.elems[:len(r.elems)-1], "*", globStar)
+	}
+	return r, true
+}
@@ -0,0 +1,149 @@
+package pathtype_test
+
+import (
+	"io/fs"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func TestIgnoreMatch(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		name     path
+		isDir    bool
+		ignored  bool
+	}{
+		{[]string{"*.log"}, "a.log", false, true},
+		{[]string{"*.log"}, "dir/to/a.log", false, true},
+		{[]string{"*.log"}, "a.txt", false, false},
+		{[]string{"*.log", "!keep.log"}, "dir/keep.log", false, false},
+		{[]string{"!keep.log", "*.log"}, "dir/keep.log", false, true},
+		{[]string{"/build"}, "build", true, true},
+		{[]string{"/build"}, "dir/build", true, false},
+		{[]string{"build/"}, "dir/build", true, true},
+		{[]string{"build/"}, "dir/build", false, false},
+		{[]string{"build/"}, "dir/build/out.o", false, true},
+		{[]string{"build/", "!build/keep.o"}, "build/keep.o", false, true},
+		{[]string{"doc/*.txt"}, "doc/a.txt", false, true},
+		{[]string{"doc/*.txt"}, "doc/sub/a.txt", false, false},
+		{[]string{"doc/*.txt"}, "sub/doc/a.txt", false, false},
+		{[]string{"**/foo"}, "a/b/foo", false, true},
+		{[]string{"**/foo/bar"}, "a/foo/bar", false, true},
+		{[]string{"abc/**"}, "abc", true, false},
+		{[]string{"abc/**"}, "abc/x/y", false, true},
+		{[]string{"a/**/b"}, "a/b", false, true},
+		{[]string{"a/**/b"}, "a/x/y/b", false, true},
+		{[]string{"a/**/b"}, "x/a/b", false, false},
+		{[]string{"# comment", "", "   "}, "# comment", false, false},
+		{[]string{`\#notes`}, "#notes", false, true},
+		{[]string{`\!important`}, "!important", false, true},
+		{[]string{"trailing   "}, "trailing", false, true},
+		{[]string{`space\ `}, "space ", false, true},
+		{[]string{"[invalid"}, "[invalid", false, false},
+	}
+	for _, test := range tests {
+		var ig pt.Ignore
+		ig.AddPatterns(".", test.patterns...)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.ignored)
+		t1.Result(ig.Match(test.name, test.isDir))
+		t1.AssertEquals()
+	}
+}
+
+func TestIgnoreMatchBase(t *testing.T) {
+	var ig pt.Ignore
+	ig.AddPatterns("/repo/sub", "*.o", "/local")
+	tests := []struct {
+		name    path
+		ignored bool
+	}{
+		{"/repo/sub/a.o", true},
+		{"/repo/sub/x/a.o", true},
+		{"/repo/a.o", false},
+		{"/repo/subdir/a.o", false},
+		{"/repo/sub/local", true},
+		{"/repo/sub/x/local", false},
+		{"/repo/sub", false},
+		{"relative/a.o", false},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.ignored)
+		t1.Result(ig.Match(test.name, false))
+		t1.AssertEquals()
+	}
+}
+
+func TestIgnoreWalkDirFunc(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("dir/to/walk/skip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+
+	files := map[path]string{
+		".gitignore":                 "*.log\n/build/\n!keep.log\n",
+		"a.log":                      "",
+		"keep.log":                   "",
+		"main.go":                    "",
+		"build/out.o":                "",
+		"dir/build/out.o":            "",
+		"dir/.gitignore":             "to/walk/\n!a.log\n",
+		"dir/a.log":                  "",
+		"dir/b.log":                  "",
+		"dir/to/c.go":                "",
+		"dir/to/walk/skip/d.go":      "",
+		"other/a.log":                "",
+		"other/.gitignore":           "# nothing here\n",
+		"other/nested/.gitignore":    "*.go\n",
+		"other/nested/e.go":          "",
+		"other/nested/f.txt":         "",
+		"other/nested/deeper/keep.c": "",
+	}
+	for p, data := range files {
+		p = tmpDir.Join(p)
+		if err := p.Dir().MkdirAll(0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.WriteFile([]byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expect := []string{
+		".",
+		".gitignore",
+		"dir",
+		"dir/.gitignore",
+		"dir/a.log",
+		"dir/build",
+		"dir/build/out.o",
+		"dir/to",
+		"dir/to/c.go",
+		"keep.log",
+		"main.go",
+		"other",
+		"other/.gitignore",
+		"other/nested",
+		"other/nested/.gitignore",
+		"other/nested/deeper",
+		"other/nested/deeper/keep.c",
+		"other/nested/f.txt",
+	}
+	var result []string
+	var ig pt.Ignore
+	err = tmpDir.WalkDir(ig.WalkDirFunc(func(p path, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := tmpDir.Rel(p)
+		result = append(result, string(rel.ToSlash()))
+		return err
+	}))
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(expect, nil)
+	t1.Result(result, err)
+	t1.AssertEquals()
+}
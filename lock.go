@@ -0,0 +1,94 @@
+package pathtype
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// ErrLocked is the error, wrapped in an *os.PathError, returned by TryLock
+// when the file is locked by someone else.
+var ErrLocked = errors.New("file is locked")
+
+// FileLock is an advisory lock on a file, obtained with Lock, RLock,
+// TryLock or LockContext and released with Unlock.
+//
+// Locks are held on behalf of an open file, not of a process: two locks
+// on the same path conflict even if they are taken by the same process,
+// and a lock is released when the process exits. Being advisory, the lock
+// keeps out only the programs that lock the file too.
+type FileLock struct {
+	f *os.File
+}
+
+// Lock waits until it gets an exclusive lock on the file at path, creating
+// it if necessary.
+//
+// Locking is implemented with flock(2) on Linux, macOS and the BSDs, and
+// fails on other systems.
+// If there is an error, it will be of type *os.PathError.
+func (path Path) Lock() (*FileLock, error) {
+	return path.lock(false, true)
+}
+
+// RLock waits until it gets a shared lock on the file at path, creating it
+// if necessary. Any number of shared locks can be held on a file at once,
+// but not together with an exclusive lock.
+// If there is an error, it will be of type *os.PathError.
+func (path Path) RLock() (*FileLock, error) {
+	return path.lock(true, true)
+}
+
+// TryLock is like Lock, but if the file is already locked it returns
+// ErrLocked instead of waiting.
+// If there is an error, it will be of type *os.PathError.
+func (path Path) TryLock() (*FileLock, error) {
+	return path.lock(false, false)
+}
+
+// LockContext is like Lock, but gives up waiting when ctx is done, and then
+// returns ctx.Err(). While it waits, it retries TryLock at increasing
+// intervals of up to a tenth of a second.
+func (path Path) LockContext(ctx context.Context) (*FileLock, error) {
+	delay := time.Millisecond
+	for {
+		l, err := path.TryLock()
+		if !errors.Is(err, ErrLocked) {
+			return l, err
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		if delay *= 2; delay > 100*time.Millisecond {
+			delay = 100 * time.Millisecond
+		}
+	}
+}
+
+func (path Path) lock(shared, block bool) (*FileLock, error) {
+	f, err := path.OpenFile(os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f, shared, block); err != nil {
+		f.Close()
+		return nil, &fs.PathError{Op: "lock", Path: string(path), Err: err}
+	}
+	return &FileLock{f: f}, nil
+}
+
+// Unlock releases the lock.
+// If there is an error, it will be of type *os.PathError.
+func (l *FileLock) Unlock() error {
+	if err := unlockFile(l.f); err != nil {
+		l.f.Close()
+		return &fs.PathError{Op: "unlock", Path: l.f.Name(), Err: err}
+	}
+	return l.f.Close()
+}
@@ -0,0 +1,19 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package pathtype
+
+import (
+	"errors"
+	"os"
+)
+
+var errLockUnsupported = errors.New("file locking not supported on this system")
+
+func lockFile(f *os.File, shared, block bool) error {
+	return errLockUnsupported
+}
+
+func unlockFile(f *os.File) error {
+	return errLockUnsupported
+}
@@ -0,0 +1,179 @@
+package pathtype_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func prepareLockTest(t *testing.T) (tmpDir path, lockFile path) {
+	t.Helper()
+	switch runtime.GOOS {
+	case "darwin", "dragonfly", "freebsd", "linux", "netbsd", "openbsd":
+	default:
+		t.Skip("file locking not supported on", runtime.GOOS)
+	}
+	tmpDir, err := prepareTestDirTree("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tmpDir, tmpDir.Join("lock")
+}
+
+func TestLock(t *testing.T) {
+	tmpDir, p := prepareLockTest(t)
+	defer tmpDir.RemoveAll()
+
+	l, err := p.Lock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, errTry := p.TryLock()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, errCtx := p.LockContext(ctx)
+
+	locked := make(chan *pt.FileLock)
+	go func() {
+		l, err := p.Lock()
+		if err != nil {
+			t.Error(err)
+		}
+		locked <- l
+	}()
+	var early bool
+	select {
+	case <-locked:
+		early = true
+	case <-time.After(20 * time.Millisecond):
+	}
+	errUnlock := l.Unlock()
+	l = <-locked
+	errUnlock2 := l.Unlock()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(true, context.DeadlineExceeded, false, nil, nil)
+	t1.Result(errors.Is(errTry, pt.ErrLocked), errCtx, early, errUnlock, errUnlock2)
+	t1.AssertEquals()
+}
+
+func TestRLock(t *testing.T) {
+	tmpDir, p := prepareLockTest(t)
+	defer tmpDir.RemoveAll()
+
+	var wg sync.WaitGroup
+	locks := make([]*pt.FileLock, 4)
+	for i := range locks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l, err := p.RLock()
+			if err != nil {
+				t.Error(err)
+			}
+			locks[i] = l
+		}(i)
+	}
+	wg.Wait()
+	_, errTry := p.TryLock()
+	for _, l := range locks {
+		l.Unlock()
+	}
+	l, errTry2 := p.TryLock()
+	if errTry2 == nil {
+		l.Unlock()
+	}
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(true, nil)
+	t1.Result(errors.Is(errTry, pt.ErrLocked), errTry2)
+	t1.AssertEquals()
+}
+
+func TestLockCounter(t *testing.T) {
+	tmpDir, p := prepareLockTest(t)
+	defer tmpDir.RemoveAll()
+	counter := tmpDir.Join("counter")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				l, err := p.LockContext(context.Background())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				data, _ := counter.ReadFile()
+				counter.WriteFile(append(data, 'x'), 0644)
+				l.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	data, _ := counter.ReadFile()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(80)
+	t1.Result(len(data))
+	t1.AssertEquals()
+}
+
+// TestLockHelperProcess is not a real test. It is run by TestLockProcess
+// in a subprocess, where it holds a lock until its standard input closes.
+func TestLockHelperProcess(t *testing.T) {
+	p := os.Getenv("PATHTYPE_LOCK_HELPER")
+	if p == "" {
+		return
+	}
+	l, err := path(p).Lock()
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Stdout.WriteString("locked\n")
+	bufio.NewReader(os.Stdin).ReadString('\n')
+	l.Unlock()
+	os.Exit(0)
+}
+
+func TestLockProcess(t *testing.T) {
+	tmpDir, p := prepareLockTest(t)
+	defer tmpDir.RemoveAll()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestLockHelperProcess$")
+	cmd.Env = append(os.Environ(), "PATHTYPE_LOCK_HELPER="+string(p))
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	line, _ := bufio.NewReader(stdout).ReadString('\n')
+	_, errTry := p.TryLock()
+	stdin.Close()
+	errWait := cmd.Wait()
+	l, errLock := p.Lock()
+	if errLock == nil {
+		l.Unlock()
+	}
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect("locked\n", true, nil, nil)
+	t1.Result(line, errors.Is(errTry, pt.ErrLocked), errWait, errLock)
+	t1.AssertEquals()
+}
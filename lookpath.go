@@ -0,0 +1,67 @@
+package pathtype
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// LookPath searches for an executable named name in the directories dirs,
+// or in the directories named by the PATH environment variable if dirs is
+// empty, and returns the path of the first one found. An empty directory
+// stands for the current directory, as it does in PATH. A file found in the
+// current directory is returned as "./name", so that the result names the
+// file rather than being searched for again by exec.Command.
+//
+// If name contains a separator, it is not searched for; LookPath only
+// checks that it names an executable. A file is executable if access(2)
+// with X_OK allows it on Unix, if one of its mode's execute bits is set
+// on Plan 9, and if its extension is listed in PATHEXT on Windows, where
+// those extensions are also tried in turn when name has none.
+//
+// If there is an error, it will be of type *exec.Error.
+func LookPath(name string, dirs ...Path) (Path, error) {
+	res, err := lookPath(name, dirs, false)
+	if err != nil {
+		return "", err
+	}
+	return res[0], nil
+}
+
+// LookPathAll is like LookPath but returns every executable named name in
+// the search list, in the order of the list, rather than only the first.
+// If there is none, it returns an *exec.Error.
+func LookPathAll(name string, dirs ...Path) ([]Path, error) {
+	return lookPath(name, dirs, true)
+}
+
+func lookPath(name string, dirs []Path, all bool) ([]Path, error) {
+	if strings.ContainsAny(name, pathSeparators) {
+		p, err := findExecutable(Path(name))
+		if err != nil {
+			return nil, &exec.Error{Name: name, Err: err}
+		}
+		return []Path{p}, nil
+	}
+	if len(dirs) == 0 {
+		dirs = SplitList(os.Getenv(pathEnv))
+	}
+	var res []Path
+	for _, dir := range dirs {
+		p := dir.Join(Path(name))
+		if !strings.ContainsAny(string(p), pathSeparators) {
+			// Join drops the "." of the current directory.
+			p = Path("."+string(os.PathSeparator)) + p
+		}
+		if p, err := findExecutable(p); err == nil {
+			res = append(res, p)
+			if !all {
+				break
+			}
+		}
+	}
+	if len(res) == 0 {
+		return nil, &exec.Error{Name: name, Err: exec.ErrNotFound}
+	}
+	return res, nil
+}
@@ -0,0 +1,29 @@
+//go:build !windows && !plan9 && !wasm
+// +build !windows,!plan9,!wasm
+
+package pathtype
+
+import "syscall"
+
+const (
+	pathEnv        = "PATH"
+	pathSeparators = "/"
+)
+
+// xOK is the X_OK mode of access(2).
+const xOK = 1
+
+// findExecutable returns p if it is an executable file.
+func findExecutable(p Path) (Path, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", syscall.EISDIR
+	}
+	if err := syscall.Access(string(p), xOK); err != nil {
+		return "", err
+	}
+	return p, nil
+}
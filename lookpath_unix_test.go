@@ -0,0 +1,86 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package pathtype_test
+
+import (
+	"errors"
+	"io/fs"
+	"os/exec"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func TestLookPath(t *testing.T) {
+	tmpDir, err := prepareTestDirTree("d1/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	if err := tmpDir.Join("d2").Mkdir(0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[path]fs.FileMode{
+		"d1/tool": 0755,
+		"d2/tool": 0700,
+		"d2/only": 0755,
+		"d1/data": 0644,
+	}
+	for p, mode := range files {
+		if err := tmpDir.Join(p).WriteFile(nil, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d1, d2 := tmpDir.Join("d1"), tmpDir.Join("d2")
+	setenv(t, "PATH", string(d2))
+
+	tests := []struct {
+		desc   string
+		name   string
+		dirs   []path
+		all    bool
+		expect []string
+		err    error
+	}{
+		{"first match", "tool", []path{d1, d2}, false, []string{string(d1.Join("tool"))}, nil},
+		{"PATH", "tool", nil, false, []string{string(d2.Join("tool"))}, nil},
+		{"all matches", "tool", []path{d1, d2}, true, []string{string(d1.Join("tool")), string(d2.Join("tool"))}, nil},
+		{"not executable", "data", []path{d1, d2}, false, nil, exec.ErrNotFound},
+		{"directory", "dir", []path{d1, d2}, false, nil, exec.ErrNotFound},
+		{"with separator", string(d2.Join("only")), []path{d1}, false, []string{string(d2.Join("only"))}, nil},
+		{"not executable with separator", string(d1.Join("data")), nil, false, nil, fs.ErrPermission},
+		{"no match", "none", []path{d1, d2}, true, nil, exec.ErrNotFound},
+		{"empty directory", "tool", []path{""}, false, []string{"./tool"}, nil},
+		{"current directory", "tool", []path{"."}, false, []string{"./tool"}, nil},
+	}
+	oldWd, err := pt.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer oldWd.Chdir()
+	if err := d1.Chdir(); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var res []path
+			var err error
+			if test.all {
+				res, err = pt.LookPathAll(test.name, test.dirs...)
+			} else {
+				var p path
+				if p, err = pt.LookPath(test.name, test.dirs...); err == nil {
+					res = []path{p}
+				}
+			}
+			var execErr *exec.Error
+			isExecErr := errors.As(err, &execErr) && execErr.Name == test.name
+
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect, true, test.err != nil)
+			t1.Result(res, errors.Is(err, test.err), isExecErr)
+			t1.AssertEquals()
+		})
+	}
+}
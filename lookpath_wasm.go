@@ -0,0 +1,20 @@
+package pathtype
+
+import "io/fs"
+
+const (
+	pathEnv        = "PATH"
+	pathSeparators = "/"
+)
+
+// findExecutable returns p if it is an executable file.
+func findExecutable(p Path) (Path, error) {
+	info, err := p.Stat()
+	if err != nil {
+		return "", err
+	}
+	if m := info.Mode(); m.IsDir() || m&0111 == 0 {
+		return "", fs.ErrPermission
+	}
+	return p, nil
+}
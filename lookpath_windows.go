@@ -0,0 +1,61 @@
+package pathtype
+
+import (
+	"io/fs"
+	"os"
+	"strings"
+)
+
+const (
+	pathEnv        = "PATH"
+	pathSeparators = `\/:`
+)
+
+// pathExts returns the executable extensions listed in PATHEXT.
+func pathExts() []string {
+	var exts []string
+	for _, e := range strings.Split(strings.ToLower(os.Getenv("PATHEXT")), ";") {
+		if e == "" {
+			continue
+		}
+		if e[0] != '.' {
+			e = "." + e
+		}
+		exts = append(exts, e)
+	}
+	if len(exts) == 0 {
+		exts = []string{".com", ".exe", ".bat", ".cmd"}
+	}
+	return exts
+}
+
+// findExecutable returns p, or p with one of the PATHEXT extensions added
+// if it has none, if it is an executable file.
+func findExecutable(p Path) (Path, error) {
+	exts := pathExts()
+	if ext := strings.ToLower(string(p.Ext())); ext != "" {
+		for _, e := range exts {
+			if ext == e {
+				return p, isFile(p)
+			}
+		}
+		return "", fs.ErrPermission
+	}
+	for _, e := range exts {
+		if q := p + Path(e); isFile(q) == nil {
+			return q, nil
+		}
+	}
+	return "", fs.ErrNotExist
+}
+
+func isFile(p Path) error {
+	info, err := p.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return fs.ErrPermission
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package pathtype
+
+import (
+	"os"
+)
+
+// rename is the function Move uses to rename files, replaced in tests.
+var rename = os.Rename
+
+// Move moves path to dst. It first tries Rename, which is atomic but only
+// works within a single file system. If the rename fails because path and
+// dst are on different devices, Move copies path, or the whole tree rooted
+// at path, to a temporary name in the directory of dst, flushes the copy
+// to stable storage, renames it to dst, and then removes path.
+//
+// The copy keeps the mode and modification time of every file and
+// directory, and their owner when the process is privileged enough to set
+// it. Symbolic links are copied as links.
+//
+// If the copy fails, the partial copy is removed and path is left untouched.
+// If path cannot be removed once the copy is in place, Move returns the
+// error and both path and dst exist.
+func (path Path) Move(dst Path) error {
+	err := rename(string(path), string(dst))
+	if err == nil || !isCrossDevice(err) {
+		return err
+	}
+
+	info, err := path.Lstat()
+	if err != nil {
+		return err
+	}
+	opts := CopyOptions{
+		PreserveMode:  true,
+		PreserveOwner: os.Geteuid() == 0,
+		PreserveTimes: true,
+		Sync:          true,
+	}
+	// The copy is made next to dst rather than in a subdirectory, since
+	// Plan 9 can only rename a file within its directory. Its temporary
+	// name is held by an empty directory, which CopyTree fills, or by an
+	// empty file, which the copy replaces.
+	var tmp Path
+	if info.IsDir() {
+		tmp, err = dst.Dir().MkdirTemp(".move-*")
+	} else {
+		var f *os.File
+		if f, err = dst.Dir().CreateTemp(".move-*"); err == nil {
+			tmp = Path(f.Name())
+			err = f.Close()
+		}
+		opts.Overwrite = OverwriteAlways
+	}
+	if err != nil {
+		if tmp != "" {
+			tmp.Remove()
+		}
+		return err
+	}
+	moved := false
+	defer func() {
+		if !moved {
+			tmp.RemoveAll()
+		}
+	}()
+	if err := path.CopyTree(tmp, opts); err != nil {
+		return err
+	}
+	if err := rename(string(tmp), string(dst)); err != nil {
+		return err
+	}
+	moved = true
+	if err := syncDir(dst.Dir()); err != nil {
+		return err
+	}
+	return path.RemoveAll()
+}
@@ -0,0 +1,19 @@
+package pathtype
+
+import (
+	"errors"
+	"os"
+)
+
+// isCrossDevice reports whether err is the error returned by Rename when
+// the old and new paths cannot be renamed into each other. Plan 9 cannot
+// rename a file into a different directory at all, and Rename fails with
+// os.ErrInvalid.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, os.ErrInvalid)
+}
+
+// syncDir does nothing: Plan 9 has no way to flush a directory.
+func syncDir(dir Path) error {
+	return nil
+}
@@ -0,0 +1,29 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package pathtype
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isCrossDevice reports whether err is the error returned by Rename when
+// the old and new paths are on different devices.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
+
+// syncDir flushes the directory dir, and so the entries created or removed
+// in it, to stable storage.
+func syncDir(dir Path) error {
+	f, err := dir.Open()
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
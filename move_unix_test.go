@@ -0,0 +1,75 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package pathtype_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+// crossDeviceRename returns a rename function that fails with EXDEV when
+// renaming from below src, and otherwise calls next.
+func crossDeviceRename(src path, next func(oldpath, newpath string) error) func(oldpath, newpath string) error {
+	return func(oldpath, newpath string) error {
+		if rel, err := src.Rel(path(oldpath)); err == nil && !strings.HasPrefix(string(rel), "..") {
+			return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+		}
+		return next(oldpath, newpath)
+	}
+}
+
+func TestMove(t *testing.T) {
+	tmpDir := prepareCopyTestTree(t)
+	defer func() {
+		tmpDir.Join("moved/a/b").Chmod(0755)
+		tmpDir.RemoveAll()
+	}()
+	src := tmpDir.Join("src")
+	expect := listTree(t, src)
+	info, _ := src.Join("a").Stat()
+
+	defer pt.SetRename(crossDeviceRename(src, os.Rename))()
+	err1 := src.Join("f.txt").Move(tmpDir.Join("f.txt"))
+	data, _ := tmpDir.Join("f.txt").ReadFile()
+	err2 := src.Join("f.txt").Move(src.Join("f.txt"))
+	err3 := tmpDir.Join("f.txt").Move(src.Join("f.txt"))
+	err4 := src.Move(tmpDir.Join("moved"))
+	minfo, _ := tmpDir.Join("moved/a").Stat()
+	_, srcErr := src.Lstat()
+	entries, _ := tmpDir.ReadDir()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, "f", true, nil, nil, expect, info.ModTime(), true, 1)
+	t1.Result(err1, string(data), errors.Is(err2, fs.ErrNotExist), err3, err4,
+		listTree(t, tmpDir.Join("moved")), minfo.ModTime(), errors.Is(srcErr, fs.ErrNotExist), len(entries))
+	t1.AssertEquals()
+}
+
+func TestMoveFailure(t *testing.T) {
+	tmpDir := prepareCopyTestTree(t)
+	defer func() {
+		tmpDir.Join("src/a/b").Chmod(0755)
+		tmpDir.RemoveAll()
+	}()
+	src := tmpDir.Join("src")
+	expect := listTree(t, src)
+
+	errFail := errors.New("rename failed")
+	defer pt.SetRename(crossDeviceRename(src, func(oldpath, newpath string) error {
+		return errFail
+	}))()
+	err := src.Move(tmpDir.Join("moved"))
+	entries, _ := tmpDir.ReadDir()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(errFail, expect, 1)
+	t1.Result(err, listTree(t, src), len(entries))
+	t1.AssertEquals()
+}
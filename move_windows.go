@@ -0,0 +1,22 @@
+package pathtype
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errorNotSameDevice is ERROR_NOT_SAME_DEVICE, returned by MoveFileEx when
+// a file cannot be moved to a different disk drive.
+const errorNotSameDevice syscall.Errno = 17
+
+// isCrossDevice reports whether err is the error returned by Rename when
+// the old and new paths are on different devices.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, errorNotSameDevice)
+}
+
+// syncDir does nothing: directories cannot be flushed on Windows, and
+// the entries of a directory are flushed with the directory's volume.
+func syncDir(dir Path) error {
+	return nil
+}
@@ -176,6 +176,31 @@ func (path Path) OpenFile(flag int, perm os.FileMode) (*os.File, error) {
 	return os.OpenFile(string(path), flag, perm)
 }
 
+// ReadDir reads the directory at path,
+// returning all its directory entries sorted by filename.
+// If an error occurs reading the directory,
+// ReadDir returns the entries it was able to read before the error,
+// along with the error.
+//
+// Unlike os.ReadDir, the returned entries know the directory they were
+// read from; see DirEntry.Path.
+func (path Path) ReadDir() ([]DirEntry, error) {
+	res, err := os.ReadDir(string(path))
+	entries := make([]DirEntry, 0, len(res))
+	for _, d := range res {
+		entries = append(entries, DirEntry{DirEntry: d, dir: path})
+	}
+	return entries, err
+}
+
+// ReadFile reads the file at path and returns the contents.
+// A successful call returns err == nil, not err == EOF.
+// Because ReadFile reads the whole file, it does not treat an EOF from Read
+// as an error to be reported.
+func (path Path) ReadFile() ([]byte, error) {
+	return os.ReadFile(string(path))
+}
+
 // Readlink returns the destination of the symbolic link at path.
 // If there is an error, it will be of type *os.PathError.
 func (path Path) Readlink() (Path, error) {
@@ -231,3 +256,16 @@ func (path Path) Truncate(size int64) error {
 func (path Path) WriteFile(data []byte, perm os.FileMode) error {
 	return os.WriteFile(string(path), data, perm)
 }
+
+// DirEntry is an fs.DirEntry read by ReadDir that also remembers the
+// directory it was read from.
+type DirEntry struct {
+	fs.DirEntry
+	dir Path
+}
+
+// Path returns the full path of the entry: the directory passed to ReadDir
+// joined with the entry's name.
+func (d DirEntry) Path() Path {
+	return d.dir.Join(Path(d.Name()))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -281,6 +282,46 @@ func TestOpenFile(t *testing.T) {
 	}
 }
 
+func TestReadDir(t *testing.T) {
+	d := createFilesInTmp(testFiles)
+	defer d.RemoveAll()
+	for _, p := range append(testPaths, d) {
+		entries, err := p.ReadDir()
+		var names, paths []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+			paths = append(paths, string(e.Path()))
+		}
+		expect, err1 := os.ReadDir(string(p))
+		var expectNames, expectPaths []string
+		for _, e := range expect {
+			expectNames = append(expectNames, e.Name())
+			expectPaths = append(expectPaths, filepath.Join(string(p), e.Name()))
+		}
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(expectNames, expectPaths, err1)
+		t1.Result(names, paths, err)
+		t1.AssertEquals()
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	d := createFilesInTmp(testFiles)
+	defer d.RemoveAll()
+	for _, p := range testFiles {
+		d.Join(p).WriteFile([]byte(p), 0644)
+	}
+	for _, paths := range [][]path{testPaths, testFiles} {
+		for _, p := range paths {
+			p = d.Join(p)
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(os.ReadFile(string(p)))
+			t1.Result(p.ReadFile())
+			t1.AssertEquals()
+		}
+	}
+}
+
 func TestReadlink(t *testing.T) {
 	d := createFilesInTmp(testFiles)
 	defer d.RemoveAll()
@@ -212,6 +212,8 @@ var pathToString TransformFunc = func(in interface{}) interface{} {
 			out = append(out, string(p))
 		}
 		return out
+	case pt.SlashPath:
+		return string(in1)
 	default:
 		return in
 	}
@@ -0,0 +1,85 @@
+package pathtype
+
+import (
+	"os"
+	"strings"
+)
+
+// PathList is a list of paths, such as the value of the PATH, GOPATH or
+// LD_LIBRARY_PATH environment variables. The methods that modify a list
+// return a new PathList and leave the receiver unchanged.
+type PathList []Path
+
+// FromEnv returns the list of paths in the environment variable named by
+// key, split with SplitList. It is empty if the variable is unset.
+func FromEnv(key string) PathList {
+	return PathList(SplitList(os.Getenv(key)))
+}
+
+// String returns the paths in l joined by the OS-specific ListSeparator,
+// the inverse of SplitList.
+func (l PathList) String() string {
+	s := make([]string, len(l))
+	for i, p := range l {
+		s[i] = string(p)
+	}
+	return strings.Join(s, string(os.PathListSeparator))
+}
+
+// Setenv sets the environment variable named by key to l.String().
+func (l PathList) Setenv(key string) error {
+	return os.Setenv(key, l.String())
+}
+
+// Prepend returns l with paths added at the front, in the given order.
+func (l PathList) Prepend(paths ...Path) PathList {
+	res := make(PathList, 0, len(paths)+len(l))
+	return append(append(res, paths...), l...)
+}
+
+// Append returns l with paths added at the end, in the given order.
+func (l PathList) Append(paths ...Path) PathList {
+	res := make(PathList, 0, len(l)+len(paths))
+	return append(append(res, l...), paths...)
+}
+
+// Remove returns l without the paths equal to any of paths, comparing
+// cleaned paths.
+func (l PathList) Remove(paths ...Path) PathList {
+	remove := make(map[Path]bool, len(paths))
+	for _, p := range paths {
+		remove[p.Clean()] = true
+	}
+	res := make(PathList, 0, len(l))
+	for _, p := range l {
+		if !remove[p.Clean()] {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// Dedup returns l with only the first of the paths that are equal when
+// cleaned.
+func (l PathList) Dedup() PathList {
+	seen := make(map[Path]bool, len(l))
+	res := make(PathList, 0, len(l))
+	for _, p := range l {
+		if c := p.Clean(); !seen[c] {
+			seen[c] = true
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// Contains reports whether l contains p, comparing cleaned paths.
+func (l PathList) Contains(p Path) bool {
+	p = p.Clean()
+	for _, p1 := range l {
+		if p1.Clean() == p {
+			return true
+		}
+	}
+	return false
+}
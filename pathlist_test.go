@@ -0,0 +1,54 @@
+package pathtype_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+// pathList replaces the colons in s by the list separator of the OS.
+func pathList(s string) string {
+	return strings.ReplaceAll(s, ":", string(os.PathListSeparator))
+}
+
+func TestPathList(t *testing.T) {
+	setenv(t, "PATHTYPE_LIST", pathList("/usr/bin:/bin:/usr/bin/:/opt/bin"))
+	l := pt.FromEnv("PATHTYPE_LIST")
+	os.Unsetenv("PATHTYPE_UNSET")
+
+	tests := []struct {
+		desc   string
+		result interface{}
+		expect interface{}
+	}{
+		{"FromEnv", l.String(), pathList("/usr/bin:/bin:/usr/bin/:/opt/bin")},
+		{"Prepend", l.Prepend("/home/me/bin").String(), pathList("/home/me/bin:/usr/bin:/bin:/usr/bin/:/opt/bin")},
+		{"Append", l.Append("/a", "/b").String(), pathList("/usr/bin:/bin:/usr/bin/:/opt/bin:/a:/b")},
+		{"Remove", l.Remove("/usr/bin/.", "/nowhere").String(), pathList("/bin:/opt/bin")},
+		{"Dedup", l.Dedup().String(), pathList("/usr/bin:/bin:/opt/bin")},
+		{"Contains trailing separator", l.Contains("/opt/bin/"), true},
+		{"Contains unclean", l.Contains("/usr/../bin"), true},
+		{"Contains missing", l.Contains("/opt"), false},
+		{"empty String", pt.PathList(nil).String(), ""},
+		{"FromEnv unset", len(pt.FromEnv("PATHTYPE_UNSET")), 0},
+		{"unmodified", l.String(), pathList("/usr/bin:/bin:/usr/bin/:/opt/bin")},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(test.expect)
+			t1.Result(test.result)
+			t1.AssertEquals()
+		})
+	}
+
+	if err := l.Remove("/usr/bin").Setenv("PATHTYPE_LIST"); err != nil {
+		t.Fatal(err)
+	}
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(pathList("/bin:/opt/bin"))
+	t1.Result(os.Getenv("PATHTYPE_LIST"))
+	t1.AssertEquals()
+}
@@ -0,0 +1,70 @@
+package pathtype
+
+// PosixPath is a custom type representing a POSIX path, separated by
+// slashes. Its methods are purely lexical and behave as those of Path do
+// on Unix, whatever the OS running the code. Unlike a SlashPath, a
+// PosixPath follows the rules of filepath rather than those of path, and
+// has the same methods as WindowsPath.
+type PosixPath string
+
+// Base returns the last element of path.
+// Trailing slashes are removed before extracting the last element.
+// If the path is empty, Base returns ".".
+// If the path consists entirely of slashes, Base returns "/".
+func (path PosixPath) Base() PosixPath {
+	return PosixPath(posixFlavor.base(string(path)))
+}
+
+// Clean returns the shortest path name equivalent to path by purely
+// lexical processing, as Path.Clean does on Unix.
+func (path PosixPath) Clean() PosixPath {
+	return PosixPath(posixFlavor.clean(string(path)))
+}
+
+// Dir returns all but the last element of path, typically the path's directory.
+// After dropping the final element, Dir calls Clean on the path and trailing
+// slashes are removed.
+func (path PosixPath) Dir() PosixPath {
+	return PosixPath(posixFlavor.dir(string(path)))
+}
+
+// IsAbs reports whether the path is absolute, that is, begins with a slash.
+func (path PosixPath) IsAbs() bool {
+	return posixFlavor.isAbs(string(path))
+}
+
+// Join joins any number of path elements into path, separating them with
+// slashes. Empty elements are ignored. The result is Cleaned. However, if
+// the argument list is empty or all its elements are empty, Join returns
+// an empty string.
+func (path PosixPath) Join(elem ...PosixPath) PosixPath {
+	e1 := []string{string(path)}
+	for _, e := range elem {
+		e1 = append(e1, string(e))
+	}
+	return PosixPath(posixFlavor.join(e1))
+}
+
+// Rel returns a relative path that is lexically equivalent to targpath when
+// joined to path. An error is returned if targpath can't be made relative
+// to path, or if knowing the current working directory would be necessary.
+func (path PosixPath) Rel(targpath PosixPath) (PosixPath, error) {
+	res, err := posixFlavor.rel(string(path), string(targpath))
+	return PosixPath(res), err
+}
+
+// Split splits path immediately following the final slash,
+// separating it into a directory and file name component.
+// If there is no slash in path, Split returns an empty dir
+// and file set to path.
+// The returned values have the property that path = dir+file.
+func (path PosixPath) Split() (dir, file PosixPath) {
+	d, f := posixFlavor.split(string(path))
+	return PosixPath(d), PosixPath(f)
+}
+
+// VolumeName returns the leading volume name, which is always empty for a
+// POSIX path. It is there for symmetry with WindowsPath.
+func (path PosixPath) VolumeName() PosixPath {
+	return ""
+}
@@ -0,0 +1,172 @@
+package pathtype
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// maxRootLinks is the number of symbolic links a Root follows while
+// resolving a single path before it gives up.
+const maxRootLinks = 255
+
+var errTooManyLinks = errors.New("too many levels of symbolic links")
+
+// EscapeError is the error returned when resolving a path inside a Root,
+// or with SecureJoin, would leave the root directory.
+type EscapeError struct {
+	Root Path // the root directory
+	Path Path // the path that tried to leave it
+}
+
+func (e *EscapeError) Error() string {
+	return "path " + string(e.Path) + " escapes from " + string(e.Root)
+}
+
+// Root is a directory that confines the paths resolved inside it. Every
+// path given to its methods is relative to the root directory, and is
+// resolved as if the root were the root of the file system, as chroot
+// does: a leading separator, or an absolute symbolic link, leads to the
+// root directory. Unlike chroot, a ".." that would go above the root
+// directory, whether written in the path or read from a symbolic link,
+// makes the method fail with an *EscapeError instead of staying put.
+//
+// Paths are resolved one element at a time with Lstat and Readlink before
+// the operation itself. Root does not protect against a concurrent process
+// that swaps a directory for a symbolic link in between.
+type Root struct {
+	dir Path
+}
+
+// OpenRoot returns a Root for the directory at path.
+func (path Path) OpenRoot() (*Root, error) {
+	abs, err := path.Abs()
+	if err != nil {
+		return nil, err
+	}
+	info, err := abs.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "openroot", Path: string(path), Err: errors.New("not a directory")}
+	}
+	return &Root{dir: abs}, nil
+}
+
+// SecureJoin joins unsafe to root, resolving ".." elements and symbolic
+// links inside root as Root does. It fails with an *EscapeError if unsafe
+// leads outside root. The directories need not exist; the elements that do
+// not are joined lexically.
+func SecureJoin(root, unsafe Path) (Path, error) {
+	return (&Root{dir: root.Clean()}).resolve(unsafe, true)
+}
+
+// Path returns the root directory, as an absolute path.
+func (r *Root) Path() Path {
+	return r.dir
+}
+
+// Join joins elem and resolves the result inside the root directory,
+// following symbolic links. It returns the path outside the root that the
+// result stands for.
+func (r *Root) Join(elem ...Path) (Path, error) {
+	// Join the elements without cleaning, so that a symbolic link followed
+	// by ".." is resolved as the file system would.
+	s := make([]string, len(elem))
+	for i, e := range elem {
+		s[i] = string(e)
+	}
+	return r.resolve(Path(strings.Join(s, string(os.PathSeparator))), true)
+}
+
+// Open opens the file name inside the root for reading, as Path.Open does.
+func (r *Root) Open(name Path) (*os.File, error) {
+	p, err := r.resolve(name, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.Open()
+}
+
+// Create creates or truncates the file name inside the root, as
+// Path.Create does.
+func (r *Root) Create(name Path) (*os.File, error) {
+	p, err := r.resolve(name, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.Create()
+}
+
+// MkdirAll creates the directory name inside the root, along with any
+// necessary parents, as Path.MkdirAll does.
+func (r *Root) MkdirAll(name Path, perm os.FileMode) error {
+	p, err := r.resolve(name, true)
+	if err != nil {
+		return err
+	}
+	return p.MkdirAll(perm)
+}
+
+// Remove removes the file or empty directory name inside the root, as
+// Path.Remove does. If name is a symbolic link, the link itself is removed.
+func (r *Root) Remove(name Path) error {
+	p, err := r.resolve(name, false)
+	if err != nil {
+		return err
+	}
+	if p == r.dir {
+		return &fs.PathError{Op: "remove", Path: string(name), Err: fs.ErrPermission}
+	}
+	return p.Remove()
+}
+
+// resolve returns the path outside the root that name stands for. The
+// last element of name is followed if it is a symbolic link and follow is
+// true.
+func (r *Root) resolve(name Path, follow bool) (Path, error) {
+	todo := splitElems(name)
+	var done []string
+	links := 0
+	for len(todo) > 0 {
+		e := todo[0]
+		todo = todo[1:]
+		switch e {
+		case ".":
+			continue
+		case "..":
+			if len(done) == 0 {
+				return "", &EscapeError{Root: r.dir, Path: name}
+			}
+			done = done[:len(done)-1]
+			continue
+		}
+
+		p := r.dir.Join(Path(strings.Join(append(done, e), string(os.PathSeparator))))
+		info, err := p.Lstat()
+		if err != nil || info.Mode()&fs.ModeSymlink == 0 || (len(todo) == 0 && !follow) {
+			// Missing elements are joined lexically.
+			done = append(done, e)
+			continue
+		}
+		if links++; links > maxRootLinks {
+			return "", &fs.PathError{Op: "resolve", Path: string(name), Err: errTooManyLinks}
+		}
+		target, err := p.Readlink()
+		if err != nil {
+			return "", err
+		}
+		if target.IsAbs() || (len(target) > 0 && os.IsPathSeparator(target[0])) {
+			done = nil
+		}
+		todo = append(splitElems(target), todo...)
+	}
+	return r.dir.Join(Path(strings.Join(done, string(os.PathSeparator)))), nil
+}
+
+// splitElems splits p into its elements, dropping any volume name.
+func splitElems(p Path) []string {
+	return splitSeparators(string(p[len(p.VolumeName()):]))
+}
@@ -0,0 +1,129 @@
+package pathtype_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func prepareRootTestTree(t *testing.T) (tmpDir path, root *pt.Root) {
+	t.Helper()
+	tmpDir, err := prepareTestDirTree("root/sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []path{"root/sub/file.txt", "secret.txt"} {
+		if err := tmpDir.Join(p).WriteFile([]byte(p), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[path]path{
+		"root/abs":      "/sub",
+		"root/etc":      "/newdir",
+		"root/flink":    "/sub/file.txt",
+		"root/escape":   "../secret.txt",
+		"root/deep":     "sub/../../secret.txt",
+		"root/sub/up":   "..",
+		"root/sub/up2":  "../..",
+		"root/loop":     "loop",
+		"root/absolute": tmpDir.Join("secret.txt"),
+	}
+	for link, target := range links {
+		if err := target.Symlink(tmpDir.Join(link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, err = tmpDir.Join("root").OpenRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tmpDir, root
+}
+
+func TestRootJoin(t *testing.T) {
+	tmpDir, root := prepareRootTestTree(t)
+	defer tmpDir.RemoveAll()
+	rootDir := root.Path()
+
+	ok := []struct {
+		name   path
+		expect path
+	}{
+		{"", ""},
+		{"/", ""},
+		{"sub/file.txt", "sub/file.txt"},
+		{"sub/../sub/./file.txt", "sub/file.txt"},
+		{"abs/file.txt", "sub/file.txt"},
+		{"etc/passwd", "newdir/passwd"},
+		{"/etc/passwd", "newdir/passwd"},
+		{"abs/up", ""},
+		{"flink", "sub/file.txt"},
+		{"sub/up/sub/up/flink", "sub/file.txt"},
+		{"missing/../sub", "sub"},
+		{"absolute", path(string(tmpDir.Join("secret.txt"))[1:])},
+	}
+	for _, test := range ok {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(string(rootDir.Join(test.expect)), nil)
+		t1.Result(root.Join(test.name))
+		t1.AssertEquals()
+	}
+
+	for _, name := range []path{"..", "../root", "sub/../..", "escape", "deep", "sub/up2", "sub/up2/secret.txt", "abs/up2"} {
+		_, err := root.Join(name)
+		var escape *pt.EscapeError
+		if !errors.As(err, &escape) || escape.Path != name || escape.Root != rootDir {
+			t.Errorf("Join(%q): got %v, want an *EscapeError", name, err)
+		}
+	}
+	if _, err := root.Join("loop"); err == nil {
+		t.Error("Join through a symbolic link loop: expected an error")
+	}
+
+	t1 := tester{TB: t, Transform: pathToString}
+	joined, err := pt.SecureJoin(tmpDir.Join("root"), "sub/up/abs/../flink")
+	_, errEscape := pt.SecureJoin(tmpDir.Join("root"), "../../x")
+	var escape *pt.EscapeError
+	t1.Expect(string(tmpDir.Join("root/sub/file.txt")), nil, true)
+	t1.Result(joined, err, errors.As(errEscape, &escape))
+	t1.AssertEquals()
+}
+
+func TestRootFiles(t *testing.T) {
+	tmpDir, root := prepareRootTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	var escape *pt.EscapeError
+	f, errOpen := root.Open("flink")
+	var data []byte
+	if errOpen == nil {
+		data = make([]byte, 64)
+		n, _ := f.Read(data)
+		data = data[:n]
+		f.Close()
+	}
+	_, errOpenEscape := root.Open("escape")
+	f, errCreate := root.Create("abs/new.txt")
+	if errCreate == nil {
+		f.Close()
+	}
+	_, errCreateEscape := root.Create("sub/up2/new.txt")
+	errMkdir := root.MkdirAll("sub/up/etc/a/b", 0755)
+	errMkdirEscape := root.MkdirAll("../outside", 0755)
+	errRemove := root.Remove("flink")
+	_, errTarget := tmpDir.Join("root/sub/file.txt").Stat()
+	errRemoveEscape := root.Remove("sub/up2/secret.txt")
+	_, errSecret := tmpDir.Join("secret.txt").Stat()
+	_, errNew := tmpDir.Join("root/sub/new.txt").Stat()
+	_, errDir := tmpDir.Join("root/newdir/a/b").Stat()
+	_, errOutside := tmpDir.Join("outside").Stat()
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, "root/sub/file.txt", true, nil, true, nil, true, nil, nil, true, nil, nil, nil, true)
+	t1.Result(errOpen, string(data), errors.As(errOpenEscape, &escape), errCreate, errors.As(errCreateEscape, &escape),
+		errMkdir, errors.As(errMkdirEscape, &escape), errRemove, errTarget, errors.As(errRemoveEscape, &escape), errSecret,
+		errNew, errDir, errors.Is(errOutside, fs.ErrNotExist))
+	t1.AssertEquals()
+}
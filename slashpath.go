@@ -0,0 +1,123 @@
+package pathtype
+
+import (
+	pathpkg "path"
+	"path/filepath"
+)
+
+// SlashPath is a custom type representing a slash-separated path, such as
+// the names used by io/fs or the paths in URLs. It is implemented by
+// wrapping the path package and never deals with OS-specific separators.
+type SlashPath string
+
+// ToSlashPath returns the result of replacing each separator character
+// in path with a slash ('/') character, as a SlashPath.
+func (path Path) ToSlashPath() SlashPath {
+	return SlashPath(filepath.ToSlash(string(path)))
+}
+
+// ToOS returns the result of replacing each slash ('/') character
+// in path with a separator character, as a Path.
+func (path SlashPath) ToOS() Path {
+	return Path(filepath.FromSlash(string(path)))
+}
+
+// Base returns the last element of path.
+// Trailing slashes are removed before extracting the last element.
+// If the path is empty, Base returns ".".
+// If the path consists entirely of slashes, Base returns "/".
+func (path SlashPath) Base() SlashPath {
+	return SlashPath(pathpkg.Base(string(path)))
+}
+
+// Clean returns the shortest path name equivalent to path
+// by purely lexical processing. It applies the following rules
+// iteratively until no further processing can be done:
+//
+//	1. Replace multiple slashes with a single slash.
+//	2. Eliminate each . path name element (the current directory).
+//	3. Eliminate each inner .. path name element (the parent directory)
+//	   along with the non-.. element that precedes it.
+//	4. Eliminate .. elements that begin a rooted path:
+//	   that is, replace "/.." by "/" at the beginning of a path.
+//
+// The returned path ends in a slash only if it is the root "/".
+//
+// If the result of this process is an empty string, Clean
+// returns the string ".".
+func (path SlashPath) Clean() SlashPath {
+	return SlashPath(pathpkg.Clean(string(path)))
+}
+
+// Dir returns all but the last element of path, typically the path's directory.
+// After dropping the final element using Split, the path is Cleaned and trailing
+// slashes are removed.
+// If the path is empty, Dir returns ".".
+// If the path consists entirely of slashes followed by non-slash bytes, Dir
+// returns a single slash. In any other case, the returned path does not end in a
+// slash.
+func (path SlashPath) Dir() SlashPath {
+	return SlashPath(pathpkg.Dir(string(path)))
+}
+
+// Ext returns the file name extension used by path.
+// The extension is the suffix beginning at the final dot
+// in the final slash-separated element of path;
+// it is empty if there is no dot.
+func (path SlashPath) Ext() string {
+	return pathpkg.Ext(string(path))
+}
+
+// IsAbs reports whether the path is absolute.
+func (path SlashPath) IsAbs() bool {
+	return pathpkg.IsAbs(string(path))
+}
+
+// Join joins any number of path elements into path,
+// separating them with slashes. Empty elements are ignored.
+// The result is Cleaned. However, if the argument list is
+// empty or all its elements are empty, Join returns
+// an empty string.
+func (path SlashPath) Join(elem ...SlashPath) SlashPath {
+	var e1 []string
+	e1 = append(e1, string(path))
+	for _, e := range elem {
+		e1 = append(e1, string(e))
+	}
+	return SlashPath(pathpkg.Join(e1...))
+}
+
+// Match reports whether the path matches the shell pattern.
+// The pattern syntax is:
+//
+//	pattern:
+//		{ term }
+//	term:
+//		'*'         matches any sequence of non-/ characters
+//		'?'         matches any single non-/ character
+//		'[' [ '^' ] { character-range } ']'
+//		            character class (must be non-empty)
+//		c           matches character c (c != '*', '?', '\\', '[')
+//		'\\' c      matches character c
+//
+//	character-range:
+//		c           matches character c (c != '\\', '-', ']')
+//		'\\' c      matches character c
+//		lo '-' hi   matches character c for lo <= c <= hi
+//
+// Match requires pattern to match all of name, not just a substring.
+// The only possible returned error is ErrBadPattern, when pattern
+// is malformed.
+func (path SlashPath) Match(pattern string) (bool, error) {
+	return pathpkg.Match(pattern, string(path))
+}
+
+// Split splits the path immediately following the final slash,
+// separating it into a directory and file name component.
+// If there is no slash in path, Split returns an empty dir and
+// file set to path.
+// The returned values have the property that path = dir+file.
+func (path SlashPath) Split() (dir, file SlashPath) {
+	d, f := pathpkg.Split(string(path))
+	return SlashPath(d), SlashPath(f)
+}
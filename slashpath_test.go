@@ -0,0 +1,112 @@
+package pathtype_test
+
+import (
+	pathpkg "path"
+	"path/filepath"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func TestToSlashPath(t *testing.T) {
+	for _, p := range testPaths {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(filepath.ToSlash(string(p)))
+		t1.Result(p.ToSlashPath())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathToOS(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(filepath.FromSlash(string(p)))
+		t1.Result(sp.ToOS())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathBase(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathpkg.Base(string(p)))
+		t1.Result(sp.Base())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathClean(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathpkg.Clean(string(p)))
+		t1.Result(sp.Clean())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathDir(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathpkg.Dir(string(p)))
+		t1.Result(sp.Dir())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathExt(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathpkg.Ext(string(p)))
+		t1.Result(sp.Ext())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathIsAbs(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathpkg.IsAbs(string(p)))
+		t1.Result(sp.IsAbs())
+		t1.AssertEquals()
+	}
+}
+
+func TestSlashPathJoin(t *testing.T) {
+	for _, p := range testPaths {
+		for _, p1 := range testPaths {
+			sp := pt.SlashPath(p)
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(pathpkg.Join(string(p), string(p1)))
+			t1.Result(sp.Join(pt.SlashPath(p1)))
+			t1.AssertEquals()
+		}
+	}
+}
+
+func TestSlashPathMatch(t *testing.T) {
+	for _, p := range testPaths {
+		for _, p1 := range testPatterns {
+			sp := pt.SlashPath(p)
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(pathpkg.Match(p1, string(p)))
+			t1.Result(sp.Match(p1))
+			t1.AssertEquals()
+		}
+	}
+}
+
+func TestSlashPathSplit(t *testing.T) {
+	for _, p := range testPaths {
+		sp := pt.SlashPath(p)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(pathpkg.Split(string(p)))
+		t1.Result(sp.Split())
+		t1.AssertEquals()
+	}
+}
@@ -0,0 +1,172 @@
+package pathtype
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+)
+
+// StopAfterLevel is used as a return value from the callbacks of
+// WalkBreadth to indicate that the walk should stop once every entry at
+// the current depth has been visited. It is not returned as an error by
+// any function.
+var StopAfterLevel = errors.New("stop after this level")
+
+// ErrQueueFull is returned by WalkBreadth when more directories are waiting
+// to be read than BreadthOptions.MaxQueue allows.
+var ErrQueueFull = errors.New("breadth-first walk queue is full")
+
+// DefaultMaxQueue is the number of directories WalkBreadth lets wait to be
+// read when BreadthOptions.MaxQueue is zero.
+const DefaultMaxQueue = 1 << 20
+
+// BreadthOptions configures WalkBreadth.
+type BreadthOptions struct {
+	// MaxQueue is the largest number of directories allowed to wait to
+	// be read. If zero, DefaultMaxQueue is used.
+	MaxQueue int
+
+	// OnLevel, if non-nil, is called after every entry at a depth has
+	// been visited, with the depth and the number of entries visited at
+	// it. The root has depth 0. If OnLevel returns StopAfterLevel, the
+	// walk stops; if it returns any other non-nil error, WalkBreadth
+	// returns that error.
+	OnLevel func(depth, width int) error
+}
+
+// WalkBreadth walks the file tree rooted at path in breadth-first order,
+// calling fn for each file or directory in the tree, including path.
+// Every entry at one depth is visited before any entry at the next depth;
+// the entries of each directory are visited in lexical order, and the
+// directories at one depth are read in the order they were visited.
+//
+// The return value of fn controls the walk as it does for WalkDir:
+// filepath.SkipDir for a directory keeps it from being read, and for a
+// file skips the remaining entries of the containing directory. If a
+// directory cannot be read, fn is called a second time for the directory
+// with the error when its turn to be read comes. In addition, fn may return
+// StopAfterLevel to finish the current depth and then stop. Any other
+// non-nil error stops the walk immediately and is returned by WalkBreadth.
+//
+// The directories waiting to be read are kept in a queue of at most
+// opts.MaxQueue entries; if a depth holds more directories than that,
+// WalkBreadth returns ErrQueueFull.
+//
+// WalkBreadth does not follow symbolic links.
+func (path Path) WalkBreadth(opts BreadthOptions, fn WalkDirFunc) error {
+	w := &breadthWalk{
+		opts:    opts,
+		fn:      fn,
+		stat:    Path.Lstat,
+		readDir: func(p Path) ([]fs.DirEntry, error) { return os.ReadDir(string(p)) },
+		join:    func(dir Path, name string) Path { return dir.Join(Path(name)) },
+	}
+	return w.run(path)
+}
+
+// WalkBreadthFS is like WalkBreadth but walks the file tree rooted at path
+// in FS fsys.
+func (path Path) WalkBreadthFS(fsys fs.FS, opts BreadthOptions, fn WalkDirFunc) error {
+	w := &breadthWalk{
+		opts:    opts,
+		fn:      fn,
+		stat:    func(p Path) (fs.FileInfo, error) { return p.StatFS(fsys) },
+		readDir: func(p Path) ([]fs.DirEntry, error) { return p.ReadDirFS(fsys) },
+		join:    func(dir Path, name string) Path { return Path(pathpkg.Join(string(dir), name)) },
+	}
+	return w.run(path)
+}
+
+// breadthWalk holds the state of WalkBreadth and WalkBreadthFS.
+type breadthWalk struct {
+	opts    BreadthOptions
+	fn      WalkDirFunc
+	stat    func(Path) (fs.FileInfo, error)
+	readDir func(Path) ([]fs.DirEntry, error)
+	join    func(dir Path, name string) Path
+}
+
+func (w *breadthWalk) run(root Path) error {
+	maxQueue := w.opts.MaxQueue
+	if maxQueue <= 0 {
+		maxQueue = DefaultMaxQueue
+	}
+
+	info, err := w.stat(root)
+	if err != nil {
+		return w.result(w.fn(root, nil, err))
+	}
+	d := statDirEntry{info}
+	err = w.fn(root, d, nil)
+	if err == nil && w.opts.OnLevel != nil {
+		err = w.opts.OnLevel(0, 1)
+	}
+	if err != nil || !d.IsDir() {
+		return w.result(err)
+	}
+
+	queue := []dirJob{{root, d}}
+	for depth := 1; len(queue) > 0; depth++ {
+		var next []dirJob
+		width := 0
+		stop := false
+	level:
+		for _, job := range queue {
+			entries, err := w.readDir(job.path)
+			if err != nil {
+				err = w.fn(job.path, job.d, err)
+				switch err {
+				case nil:
+				case filepath.SkipDir:
+					continue level
+				case StopAfterLevel:
+					stop = true
+					continue level
+				default:
+					return err
+				}
+			}
+			for _, e := range entries {
+				p := w.join(job.path, e.Name())
+				width++
+				switch err := w.fn(p, e, nil); err {
+				case nil:
+					if e.IsDir() && !stop {
+						if len(next) >= maxQueue {
+							return ErrQueueFull
+						}
+						next = append(next, dirJob{p, e})
+					}
+				case filepath.SkipDir:
+					if !e.IsDir() {
+						continue level
+					}
+				case StopAfterLevel:
+					stop = true
+				default:
+					return err
+				}
+			}
+		}
+		if w.opts.OnLevel != nil && width > 0 {
+			if err := w.opts.OnLevel(depth, width); err != nil {
+				return w.result(err)
+			}
+		}
+		if stop {
+			return nil
+		}
+		queue = next
+	}
+	return nil
+}
+
+// result converts the error that ended a walk into the error to return.
+func (w *breadthWalk) result(err error) error {
+	if err == filepath.SkipDir || err == StopAfterLevel {
+		return nil
+	}
+	return err
+}
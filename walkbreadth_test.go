@@ -0,0 +1,182 @@
+package pathtype_test
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+// breadthLess orders slash-separated relative paths as a breadth-first walk
+// visits them: by depth, then element by element.
+func breadthLess(a, b string) bool {
+	ea, eb := strings.Split(a, "/"), strings.Split(b, "/")
+	if a == "." {
+		ea = nil
+	}
+	if b == "." {
+		eb = nil
+	}
+	if len(ea) != len(eb) {
+		return len(ea) < len(eb)
+	}
+	for i := range ea {
+		if ea[i] != eb[i] {
+			return ea[i] < eb[i]
+		}
+	}
+	return false
+}
+
+func TestWalkBreadth(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	skip := func(d fs.DirEntry) error {
+		if d.Name() == "skip" || d.Name() == "b.txt" {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+
+	var expect []string
+	filepath.WalkDir(string(tmpDir), func(p string, d fs.DirEntry, err error) error {
+		rel, _ := filepath.Rel(string(tmpDir), p)
+		expect = append(expect, filepath.ToSlash(rel))
+		return skip(d)
+	})
+	sort.SliceStable(expect, func(i, j int) bool { return breadthLess(expect[i], expect[j]) })
+
+	var result []string
+	var widths []string
+	err := tmpDir.WalkBreadth(pt.BreadthOptions{
+		OnLevel: func(depth, width int) error {
+			widths = append(widths, fmt.Sprint(depth, ":", width))
+			return nil
+		},
+	}, func(p path, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := tmpDir.Rel(p)
+		result = append(result, string(rel.ToSlash()))
+		return skip(d)
+	})
+
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(expect, []string{"0:1", "1:3", "2:7", "3:27", "4:51"}, nil)
+	t1.Result(result, widths, err)
+	t1.AssertEquals()
+}
+
+func TestWalkBreadthStopAfterLevel(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	var result []string
+	err := tmpDir.WalkBreadth(pt.BreadthOptions{}, func(p path, d fs.DirEntry, err error) error {
+		rel, _ := tmpDir.Rel(p)
+		result = append(result, string(rel.ToSlash()))
+		if d.Name() == "dir" {
+			return pt.StopAfterLevel
+		}
+		return err
+	})
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect([]string{".", "dir", "top.txt", "wide"}, nil)
+	t1.Result(result, err)
+	t1.AssertEquals()
+
+	result = nil
+	err = tmpDir.WalkBreadth(pt.BreadthOptions{
+		OnLevel: func(depth, width int) error {
+			if depth == 2 {
+				return pt.StopAfterLevel
+			}
+			return nil
+		},
+	}, func(p path, d fs.DirEntry, err error) error {
+		rel, _ := tmpDir.Rel(p)
+		result = append(result, string(rel.ToSlash()))
+		return err
+	})
+	t1 = tester{TB: t, Transform: pathToString}
+	t1.Expect(11, nil)
+	t1.Result(len(result), err)
+	t1.AssertEquals()
+}
+
+func TestWalkBreadthErrors(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	nop := func(p path, d fs.DirEntry, err error) error { return err }
+	errStop := fmt.Errorf("stop")
+	var visited int
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(pt.ErrQueueFull, nil, errStop, 3)
+	t1.Result(
+		tmpDir.WalkBreadth(pt.BreadthOptions{MaxQueue: 4}, nop),
+		tmpDir.WalkBreadth(pt.BreadthOptions{MaxQueue: 4}, func(p path, d fs.DirEntry, err error) error {
+			if d.Name() == "wide" {
+				return filepath.SkipDir
+			}
+			return err
+		}),
+		tmpDir.WalkBreadth(pt.BreadthOptions{}, func(p path, d fs.DirEntry, err error) error {
+			visited++
+			if d.Name() == "top.txt" {
+				return errStop
+			}
+			return err
+		}),
+		visited,
+	)
+	t1.AssertEquals()
+
+	var missing error
+	tmpDir.Join("missing").WalkBreadth(pt.BreadthOptions{}, func(p path, d fs.DirEntry, err error) error {
+		missing = err
+		return nil
+	})
+	if missing == nil {
+		t.Error("WalkBreadth on a missing root: no error passed to fn")
+	}
+}
+
+func TestWalkBreadthFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a/b/c/d.txt": {},
+		"a/b/e.txt":   {},
+		"a/f.txt":     {},
+		"g/h.txt":     {},
+		"i.txt":       {},
+	}
+	var result []string
+	var widths []string
+	err := path(".").WalkBreadthFS(fsys, pt.BreadthOptions{
+		OnLevel: func(depth, width int) error {
+			widths = append(widths, fmt.Sprint(depth, ":", width))
+			return nil
+		},
+	}, func(p path, d fs.DirEntry, err error) error {
+		result = append(result, string(p))
+		if p == "g" {
+			return filepath.SkipDir
+		}
+		return err
+	})
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(
+		[]string{".", "a", "g", "i.txt", "a/b", "a/f.txt", "a/b/c", "a/b/e.txt", "a/b/c/d.txt"},
+		[]string{"0:1", "1:3", "2:2", "3:2", "4:1"},
+		nil,
+	)
+	t1.Result(result, widths, err)
+	t1.AssertEquals()
+}
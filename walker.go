@@ -0,0 +1,250 @@
+package pathtype
+
+import (
+	"io"
+	"io/fs"
+	pathpkg "path"
+)
+
+// walkerBatch is the number of entries a Walker reads from a directory
+// at a time.
+const walkerBatch = 128
+
+// Walker is a cursor over the file tree rooted at a path. Each call to Next
+// advances it to the next file or directory, which is then described by
+// Path, Entry and Err, as the arguments of a WalkDirFunc would be:
+//
+//	w := root.Walker()
+//	defer w.Close()
+//	for w.Next() {
+//		if w.Err() != nil {
+//			// handle the error
+//			continue
+//		}
+//		if w.Entry().IsDir() && w.Entry().Name() == "skip" {
+//			w.SkipDir()
+//		}
+//		fmt.Println(w.Path())
+//	}
+//
+// The root is visited first. A directory is visited before its entries,
+// and its entries are visited before those of the next directory.
+// If a directory cannot be read, it is visited a second time with Err
+// reporting the problem, and the walk continues with the next entry.
+//
+// Directories are read a few entries at a time and kept open while their
+// entries are visited, so memory use depends on the depth of the tree
+// rather than the size of its directories. In exchange, the entries of a
+// directory are visited in the order the directory returns them rather
+// than in lexical order.
+//
+// Walker does not follow symbolic links. Callers that stop before Next
+// returns false must call Close.
+type Walker struct {
+	fsys    fs.FS
+	root    Path
+	started bool
+	done    bool
+	descend bool // whether Next should enter the current directory
+	skipped bool // whether SkipDir was called for the current entry
+	stack   []*walkerDir
+
+	path  Path
+	entry fs.DirEntry
+	err   error
+}
+
+// walkerDir is an open directory whose entries a Walker is visiting.
+type walkerDir struct {
+	path  Path
+	d     fs.DirEntry
+	read  func(n int) ([]fs.DirEntry, error)
+	close func() error
+	buf   []fs.DirEntry
+	eof   bool
+	err   error
+}
+
+// Walker returns a Walker over the file tree rooted at path.
+func (path Path) Walker() *Walker {
+	return &Walker{root: path}
+}
+
+// WalkerFS returns a Walker over the file tree rooted at path in FS fsys.
+// If the directories of fsys do not implement fs.ReadDirFile, each of them
+// is read in full when the Walker enters it.
+func (path Path) WalkerFS(fsys fs.FS) *Walker {
+	return &Walker{fsys: fsys, root: path}
+}
+
+// Next advances the Walker to the next file or directory, and reports
+// whether there was one. It returns false once the whole tree has been
+// visited or the Walker was closed.
+func (w *Walker) Next() bool {
+	if w.done {
+		return false
+	}
+	if !w.started {
+		w.started = true
+		info, err := w.stat(w.root)
+		if err != nil {
+			w.set(w.root, nil, err)
+			return true
+		}
+		w.set(w.root, statDirEntry{info}, nil)
+		w.descend = info.IsDir()
+		return true
+	}
+
+	if w.descend {
+		w.descend = false
+		dir, err := w.open(w.path, w.entry)
+		if err != nil {
+			w.set(w.path, w.entry, err)
+			return true
+		}
+		w.stack = append(w.stack, dir)
+	}
+
+	for len(w.stack) > 0 {
+		top := w.stack[len(w.stack)-1]
+		if len(top.buf) == 0 && !top.eof {
+			top.buf, top.err = top.read(walkerBatch)
+			if top.err != nil || len(top.buf) == 0 {
+				top.eof = true
+			}
+			if top.err == io.EOF {
+				top.err = nil
+			}
+		}
+		if len(top.buf) == 0 {
+			w.pop()
+			if top.err != nil {
+				w.set(top.path, top.d, top.err)
+				return true
+			}
+			continue
+		}
+		e := top.buf[0]
+		top.buf = top.buf[1:]
+		w.set(w.join(top.path, e.Name()), e, nil)
+		w.descend = e.IsDir()
+		return true
+	}
+	w.done = true
+	return false
+}
+
+// Path returns the path of the current file or directory.
+func (w *Walker) Path() Path {
+	return w.path
+}
+
+// Entry returns the fs.DirEntry of the current file or directory.
+// It is nil if the root could not be read.
+func (w *Walker) Entry() fs.DirEntry {
+	return w.entry
+}
+
+// Err returns the error, if any, that occurred visiting the current file
+// or directory.
+func (w *Walker) Err() error {
+	return w.err
+}
+
+// SkipDir skips the current directory: the next call to Next does not
+// enter it. If the current entry is not a directory, SkipDir skips the
+// remaining entries of the directory that contains it, as returning
+// filepath.SkipDir from a WalkDirFunc does. If the current entry is a
+// directory visited with an error, SkipDir does nothing. Calling SkipDir
+// again for the same entry does nothing either.
+func (w *Walker) SkipDir() {
+	if w.skipped {
+		return
+	}
+	w.skipped = true
+	if w.descend || w.entry == nil || (w.entry.IsDir() && w.err != nil) {
+		w.descend = false
+		return
+	}
+	if len(w.stack) > 0 {
+		w.pop()
+	}
+}
+
+// Close releases the directories held open by the Walker. After Close,
+// Next returns false.
+func (w *Walker) Close() error {
+	var err error
+	for len(w.stack) > 0 {
+		if err1 := w.pop(); err == nil {
+			err = err1
+		}
+	}
+	w.done = true
+	w.descend = false
+	return err
+}
+
+func (w *Walker) set(p Path, d fs.DirEntry, err error) {
+	w.path, w.entry, w.err = p, d, err
+	w.skipped = false
+}
+
+// pop closes the innermost open directory.
+func (w *Walker) pop() error {
+	top := w.stack[len(w.stack)-1]
+	w.stack = w.stack[:len(w.stack)-1]
+	return top.close()
+}
+
+func (w *Walker) stat(p Path) (fs.FileInfo, error) {
+	if w.fsys != nil {
+		return p.StatFS(w.fsys)
+	}
+	return p.Lstat()
+}
+
+func (w *Walker) join(dir Path, name string) Path {
+	if w.fsys != nil {
+		return Path(pathpkg.Join(string(dir), name))
+	}
+	return dir.Join(Path(name))
+}
+
+func (w *Walker) open(p Path, d fs.DirEntry) (*walkerDir, error) {
+	if w.fsys == nil {
+		f, err := p.Open()
+		if err != nil {
+			return nil, err
+		}
+		return &walkerDir{path: p, d: d, read: f.ReadDir, close: f.Close}, nil
+	}
+	f, err := p.OpenFS(w.fsys)
+	if err != nil {
+		return nil, err
+	}
+	if rf, ok := f.(fs.ReadDirFile); ok {
+		return &walkerDir{path: p, d: d, read: rf.ReadDir, close: f.Close}, nil
+	}
+	f.Close()
+	entries, err := p.ReadDirFS(w.fsys)
+	if err != nil && len(entries) == 0 {
+		return nil, err
+	}
+	read := func(n int) ([]fs.DirEntry, error) {
+		if len(entries) == 0 {
+			if err == nil {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		if n > len(entries) {
+			n = len(entries)
+		}
+		res := entries[:n]
+		entries = entries[n:]
+		return res, nil
+	}
+	return &walkerDir{path: p, d: d, read: read, close: func() error { return nil }}, nil
+}
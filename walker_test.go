@@ -0,0 +1,127 @@
+package pathtype_test
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+func TestWalker(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	for _, root := range []path{tmpDir, tmpDir.Join("top.txt"), tmpDir.Join("missing"), tmpDir.Join("wide/0")} {
+		var expect, result []string
+		filepath.WalkDir(string(root), func(p string, d fs.DirEntry, err error) error {
+			expect = append(expect, fmt.Sprint(p, err))
+			if err == nil && d.Name() == "skip" {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+
+		w := root.Walker()
+		for w.Next() {
+			result = append(result, fmt.Sprint(w.Path(), w.Err()))
+			if w.Err() == nil && w.Entry().Name() == "skip" {
+				w.SkipDir()
+			}
+		}
+		sort.Strings(expect)
+		sort.Strings(result)
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(expect, false, nil)
+		t1.Result(result, w.Next(), w.Close())
+		t1.AssertEquals()
+	}
+}
+
+func TestWalkerFS(t *testing.T) {
+	walkFsys := fstest.MapFS{
+		"a/b/c.txt":      {},
+		"a/b.txt":        {},
+		"a/c.txt":        {},
+		"a/d/e.txt":      {},
+		"e/skip/f":       {},
+		"e/g.txt":        {},
+		"hello.txt":      {},
+		"empty/dir":      {Mode: fs.ModeDir},
+		"z/y/x/w/v.txt":  {},
+		"z/y/x/b.txt":    {},
+		"z/y/x/c/d.txt":  {},
+		"z/y/x/w/.dot":   {},
+		"z/y/x/w/0.zero": {},
+	}
+	for _, p := range append(testPaths, "a", "e", "z", "hello.txt") {
+		var expect, result []string
+		fs.WalkDir(walkFsys, string(p), func(p string, d fs.DirEntry, err error) error {
+			expect = append(expect, fmt.Sprint(p, err))
+			if err == nil && (d.Name() == "skip" || d.Name() == "b.txt") {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+
+		w := p.WalkerFS(walkFsys)
+		for w.Next() {
+			result = append(result, fmt.Sprint(w.Path(), w.Err()))
+			if w.Err() == nil && (w.Entry().Name() == "skip" || w.Entry().Name() == "b.txt") {
+				w.SkipDir()
+			}
+		}
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(expect, nil)
+		t1.Result(result, w.Close())
+		t1.AssertEquals()
+	}
+}
+
+func TestWalkerSkipDirTwice(t *testing.T) {
+	walkFsys := fstest.MapFS{
+		"t/x/a": {},
+		"t/x/b": {},
+		"t/z":   {},
+	}
+	for _, name := range []string{"x", "a"} {
+		var expect, result []string
+		fs.WalkDir(walkFsys, "t", func(p string, d fs.DirEntry, err error) error {
+			expect = append(expect, p)
+			if d.Name() == name {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+
+		w := path("t").WalkerFS(walkFsys)
+		for w.Next() {
+			result = append(result, string(w.Path()))
+			if w.Entry().Name() == name {
+				w.SkipDir()
+				w.SkipDir()
+			}
+		}
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(expect, nil)
+		t1.Result(result, w.Close())
+		t1.AssertEquals()
+	}
+}
+
+func TestWalkerClose(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	w := tmpDir.Walker()
+	for i := 0; i < 10 && w.Next(); i++ {
+		if w.Err() != nil {
+			t.Fatal(w.Err())
+		}
+	}
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, false, nil)
+	t1.Result(w.Close(), w.Next(), w.Close())
+	t1.AssertEquals()
+}
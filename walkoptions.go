@@ -0,0 +1,227 @@
+package pathtype
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WalkSort selects the order in which WalkWith visits the entries of a
+// directory.
+type WalkSort int
+
+const (
+	// SortName visits entries in lexical order of their names, as WalkDir does.
+	SortName WalkSort = iota
+	// SortNatural visits entries in order of their names, comparing runs
+	// of digits by their numeric value, so that "file2" comes before "file10".
+	SortNatural
+	// SortModTime visits entries from the least to the most recently modified.
+	SortModTime
+	// SortSize visits entries from the smallest to the largest.
+	SortSize
+)
+
+// WalkOptions configures WalkWith.
+type WalkOptions struct {
+	// MinDepth is the depth of the shallowest entries passed to the
+	// callbacks. The root has depth 0, its entries depth 1, and so on.
+	// Shallower directories are still walked.
+	MinDepth int
+
+	// MaxDepth is the depth of the deepest entries passed to the
+	// callbacks; directories at this depth are not read. Zero means
+	// no limit.
+	MaxDepth int
+
+	// Sort is the order in which the entries of each directory are
+	// visited. Entries that compare equal are visited in lexical order.
+	Sort WalkSort
+
+	// Less, if non-nil, reports whether entry a should be visited before
+	// entry b, overriding Sort.
+	Less func(a, b fs.DirEntry) bool
+
+	// SkipHidden skips the files and directories whose name begins with
+	// a dot. The root is visited even if its name begins with a dot.
+	SkipHidden bool
+
+	// PreDir, if non-nil, is called for directories before their entries,
+	// in place of the callback passed to WalkWith, which then only sees
+	// the other files.
+	PreDir WalkDirFunc
+
+	// PostDir, if non-nil, is called for directories after all their
+	// entries have been visited, with a nil error. It is not called for
+	// a directory skipped by the pre-visit callback. If PostDir returns
+	// filepath.SkipDir, the remaining entries of the parent directory
+	// are skipped.
+	PostDir WalkDirFunc
+}
+
+// WalkWith walks the file tree rooted at path like WalkDir, calling fn for
+// each file or directory in the tree, including path, with the behavior
+// adjusted by opts. See WalkOptions for details.
+//
+// Errors reading the root or a directory are always passed to the pre-visit
+// callback, whatever the depth of the path they concern, with the same
+// meaning as for WalkDir.
+//
+// WalkWith does not follow symbolic links.
+func (path Path) WalkWith(opts WalkOptions, fn WalkDirFunc) error {
+	w := &optionsWalk{opts: opts, fn: fn}
+	info, err := path.Lstat()
+	if err != nil {
+		err = fn(path, nil, err)
+	} else {
+		err = w.walk(path, statDirEntry{info}, 0)
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+// optionsWalk holds the state of WalkWith.
+type optionsWalk struct {
+	opts WalkOptions
+	fn   WalkDirFunc
+}
+
+// pre returns the callback that visits d before its entries.
+func (w *optionsWalk) pre(d fs.DirEntry) WalkDirFunc {
+	if d.IsDir() && w.opts.PreDir != nil {
+		return w.opts.PreDir
+	}
+	return w.fn
+}
+
+func (w *optionsWalk) walk(path Path, d fs.DirEntry, depth int) error {
+	if depth >= w.opts.MinDepth {
+		if err := w.pre(d)(path, d, nil); err != nil {
+			if err == filepath.SkipDir && d.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+	if !d.IsDir() {
+		return nil
+	}
+
+	if w.opts.MaxDepth <= 0 || depth < w.opts.MaxDepth {
+		entries, err := os.ReadDir(string(path))
+		if err != nil {
+			err = w.pre(d)(path, d, err)
+			if err != nil {
+				if err == filepath.SkipDir {
+					err = nil
+				}
+				return err
+			}
+		}
+		for _, e := range w.sort(w.filter(entries)) {
+			if err := w.walk(path.Join(Path(e.Name())), e, depth+1); err != nil {
+				if err == filepath.SkipDir {
+					break
+				}
+				return err
+			}
+		}
+	}
+
+	if w.opts.PostDir != nil && depth >= w.opts.MinDepth {
+		return w.opts.PostDir(path, d, nil)
+	}
+	return nil
+}
+
+func (w *optionsWalk) filter(entries []fs.DirEntry) []fs.DirEntry {
+	if !w.opts.SkipHidden {
+		return entries
+	}
+	res := entries[:0]
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), ".") {
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+func (w *optionsWalk) sort(entries []fs.DirEntry) []fs.DirEntry {
+	switch {
+	case w.opts.Less != nil:
+		sort.SliceStable(entries, func(i, j int) bool { return w.opts.Less(entries[i], entries[j]) })
+	case w.opts.Sort == SortNatural:
+		sort.SliceStable(entries, func(i, j int) bool { return naturalLess(entries[i].Name(), entries[j].Name()) })
+	case w.opts.Sort == SortModTime, w.opts.Sort == SortSize:
+		// Read every FileInfo once rather than on every comparison.
+		k := keyedEntries{entries: entries, keys: make([]int64, len(entries))}
+		for i, e := range entries {
+			if info, err := e.Info(); err == nil {
+				if w.opts.Sort == SortModTime {
+					k.keys[i] = info.ModTime().UnixNano()
+				} else {
+					k.keys[i] = info.Size()
+				}
+			}
+		}
+		sort.Stable(k)
+	}
+	// Otherwise os.ReadDir already sorted the entries by name.
+	return entries
+}
+
+// keyedEntries sorts directory entries by a precomputed key.
+type keyedEntries struct {
+	entries []fs.DirEntry
+	keys    []int64
+}
+
+func (k keyedEntries) Len() int           { return len(k.entries) }
+func (k keyedEntries) Less(i, j int) bool { return k.keys[i] < k.keys[j] }
+func (k keyedEntries) Swap(i, j int) {
+	k.entries[i], k.entries[j] = k.entries[j], k.entries[i]
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+}
+
+// naturalLess reports whether a sorts before b when runs of digits are
+// compared by their numeric value.
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		da, db := isDigit(a[0]), isDigit(b[0])
+		switch {
+		case da && db:
+			na, nb := digitRun(a), digitRun(b)
+			ta, tb := strings.TrimLeft(a[:na], "0"), strings.TrimLeft(b[:nb], "0")
+			if len(ta) != len(tb) {
+				return len(ta) < len(tb)
+			}
+			if ta != tb {
+				return ta < tb
+			}
+			if na != nb {
+				return na < nb
+			}
+			a, b = a[na:], b[nb:]
+		case a[0] != b[0]:
+			return a[0] < b[0]
+		default:
+			a, b = a[1:], b[1:]
+		}
+	}
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+func digitRun(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
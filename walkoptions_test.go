@@ -0,0 +1,159 @@
+package pathtype_test
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+func prepareWalkOptionsTestTree(t *testing.T) path {
+	t.Helper()
+	tmpDir, err := prepareTestDirTree("d/sub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []struct {
+		name path
+		size int
+	}{
+		{"f10", 1},
+		{"f2", 3},
+		{"f1", 2},
+		{".hidden", 0},
+		{"d/x", 0},
+		{"d/sub/y", 0},
+		{"d/.h/z", 0},
+	}
+	now := time.Now()
+	for i, f := range files {
+		p := tmpDir.Join(f.name)
+		if err := p.Dir().MkdirAll(0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.WriteFile(make([]byte, f.size), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(-time.Duration(i) * time.Hour)
+		if err := p.Chtimes(mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+func walkWithResult(t *testing.T, root path, opts pt.WalkOptions) []string {
+	t.Helper()
+	var result []string
+	record := func(prefix string) pt.WalkDirFunc {
+		return func(p path, d fs.DirEntry, err error) error {
+			if err != nil {
+				t.Fatal(err)
+			}
+			rel, _ := root.Rel(p)
+			result = append(result, prefix+string(rel.ToSlash()))
+			return nil
+		}
+	}
+	if opts.PreDir != nil {
+		opts.PreDir = record("pre ")
+	}
+	if opts.PostDir != nil {
+		opts.PostDir = record("post ")
+	}
+	if err := root.WalkWith(opts, record("")); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestWalkWith(t *testing.T) {
+	tmpDir := prepareWalkOptionsTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	var walkDir []string
+	filepath.WalkDir(string(tmpDir), func(p string, d fs.DirEntry, err error) error {
+		rel, _ := filepath.Rel(string(tmpDir), p)
+		walkDir = append(walkDir, filepath.ToSlash(rel))
+		return err
+	})
+
+	nop := func(path, fs.DirEntry, error) error { return nil }
+	tests := []struct {
+		opts   pt.WalkOptions
+		expect []string
+	}{
+		{pt.WalkOptions{}, walkDir},
+		{pt.WalkOptions{MaxDepth: 1}, []string{".", ".hidden", "d", "f1", "f10", "f2"}},
+		{pt.WalkOptions{MinDepth: 2}, []string{"d/.h", "d/.h/z", "d/sub", "d/sub/y", "d/x"}},
+		{pt.WalkOptions{MinDepth: 2, MaxDepth: 2}, []string{"d/.h", "d/sub", "d/x"}},
+		{pt.WalkOptions{SkipHidden: true}, []string{".", "d", "d/sub", "d/sub/y", "d/x", "f1", "f10", "f2"}},
+		{pt.WalkOptions{MaxDepth: 1, Sort: pt.SortNatural}, []string{".", ".hidden", "d", "f1", "f2", "f10"}},
+		{pt.WalkOptions{MaxDepth: 1, Sort: pt.SortSize, SkipHidden: true}, []string{".", "f10", "f1", "f2", "d"}},
+		{pt.WalkOptions{MaxDepth: 1, Sort: pt.SortModTime, SkipHidden: true}, []string{".", "f1", "f2", "f10", "d"}},
+		{pt.WalkOptions{MaxDepth: 1, Less: func(a, b fs.DirEntry) bool { return a.Name() > b.Name() }}, []string{".", "f2", "f10", "f1", "d", ".hidden"}},
+		{pt.WalkOptions{SkipHidden: true, PostDir: nop}, []string{".", "d", "d/sub", "d/sub/y", "post d/sub", "d/x", "post d", "f1", "f10", "f2", "post ."}},
+		{pt.WalkOptions{SkipHidden: true, PreDir: nop, PostDir: nop, MinDepth: 1}, []string{"pre d", "pre d/sub", "d/sub/y", "post d/sub", "d/x", "post d", "f1", "f10", "f2"}},
+	}
+	for _, test := range tests {
+		t1 := tester{TB: t, Transform: pathToString}
+		t1.Expect(test.expect)
+		t1.Result(walkWithResult(t, tmpDir, test.opts))
+		t1.AssertEquals()
+	}
+}
+
+func TestWalkWithPostDirRemove(t *testing.T) {
+	tmpDir := prepareWalkOptionsTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	root := tmpDir.Join("d")
+	err := root.WalkWith(pt.WalkOptions{
+		PostDir: func(p path, d fs.DirEntry, err error) error {
+			return p.Remove()
+		},
+	}, func(p path, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return p.Remove()
+	})
+	_, statErr := root.Lstat()
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(nil, true)
+	t1.Result(err, statErr != nil && strings.Contains(statErr.Error(), "no such file"))
+	t1.AssertEquals()
+}
+
+func TestWalkWithSkipDir(t *testing.T) {
+	tmpDir := prepareWalkOptionsTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	var result []string
+	err := tmpDir.WalkWith(pt.WalkOptions{
+		PostDir: func(p path, d fs.DirEntry, err error) error {
+			result = append(result, fmt.Sprint("post ", p.Base()))
+			if p.Base() == "sub" {
+				return filepath.SkipDir
+			}
+			return nil
+		},
+	}, func(p path, d fs.DirEntry, err error) error {
+		result = append(result, string(p.Base()))
+		if p.Base() == ".h" || p.Base() == "f10" {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	expect := []string{
+		string(tmpDir.Base()), ".hidden", "d", ".h", "sub", "y", "post sub", "post d", "f1", "f10", "post " + string(tmpDir.Base()),
+	}
+	t1 := tester{TB: t, Transform: pathToString}
+	t1.Expect(expect, nil)
+	t1.Result(result, err)
+	t1.AssertEquals()
+}
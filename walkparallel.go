@@ -0,0 +1,300 @@
+package pathtype
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// WalkParallel walks the file tree rooted at path, calling fn for each file
+// or directory in the tree, including path, like WalkDir. Directories are
+// read concurrently by up to workers goroutines; if workers is less than 1,
+// runtime.GOMAXPROCS(0) is used.
+//
+// fn is called from several goroutines at once and must be safe for
+// concurrent use. The entries of a single directory are visited by one
+// goroutine in lexical order, but the visits of different directories are
+// interleaved in no particular order. The entries of a directory are only
+// visited after fn has returned nil for the directory itself.
+//
+// The return value of fn controls the walk as it does for WalkDir:
+//
+//   - If fn returns filepath.SkipDir for a directory, the directory is
+//     not read. If it returns filepath.SkipDir for a file, the remaining
+//     entries of the containing directory are skipped.
+//   - If fn returns any other non-nil error, the walk stops: no further
+//     calls to fn are started, calls already running are allowed to
+//     finish, and WalkParallel returns the first such error.
+//   - If a directory cannot be read, fn is called a second time for the
+//     directory with the error, and the entries that were read before the
+//     error are still visited unless fn returns an error.
+//
+// WalkParallel does not follow symbolic links.
+func (path Path) WalkParallel(workers int, fn WalkDirFunc) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	info, err := os.Lstat(string(path))
+	if err != nil {
+		err = fn(path, nil, err)
+	} else {
+		d := statDirEntry{info}
+		err = fn(path, d, nil)
+		if err == nil && d.IsDir() {
+			w := &parallelWalk{fn: fn}
+			w.cond = sync.NewCond(&w.mu)
+			err = w.run(dirJob{path, d}, workers)
+		}
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+// WalkParallelOrdered walks the file tree rooted at path exactly like
+// WalkDir: fn is called from a single goroutine, for the same paths and in
+// the same lexical order. While fn runs, up to workers goroutines read the
+// directories that the walk is about to enter, so that slow directory reads
+// overlap. If workers is less than 1, runtime.GOMAXPROCS(0) is used.
+//
+// At most workers directories are read ahead at any time, and a directory
+// read ahead is discarded if fn skips it.
+//
+// WalkParallelOrdered does not follow symbolic links.
+func (path Path) WalkParallelOrdered(workers int, fn WalkDirFunc) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	info, err := os.Lstat(string(path))
+	if err != nil {
+		err = fn(path, nil, err)
+	} else {
+		d := statDirEntry{info}
+		err = fn(path, d, nil)
+		if err == nil && d.IsDir() {
+			w := &orderedWalk{fn: fn, sem: make(chan struct{}, workers)}
+			err = w.walkDir(path, d, nil)
+		}
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+// dirJob is a directory waiting to be read by a parallelWalk.
+type dirJob struct {
+	path Path
+	d    fs.DirEntry
+}
+
+// parallelWalk holds the state shared by the workers of WalkParallel.
+type parallelWalk struct {
+	fn WalkDirFunc
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []dirJob // directories waiting to be read
+	pending int      // directories queued or being read
+	err     error    // first error returned by fn
+}
+
+func (w *parallelWalk) run(root dirJob, workers int) error {
+	w.push(root)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				job, ok := w.pop()
+				if !ok {
+					return
+				}
+				w.readDir(job)
+				w.done()
+			}
+		}()
+	}
+	wg.Wait()
+	return w.err
+}
+
+// readDir reads the directory of job and visits its entries.
+func (w *parallelWalk) readDir(job dirJob) {
+	entries, err := os.ReadDir(string(job.path))
+	if err != nil {
+		if w.stopped() {
+			return
+		}
+		if err := w.fn(job.path, job.d, err); err != nil {
+			if err != filepath.SkipDir {
+				w.fail(err)
+			}
+			return
+		}
+	}
+	for _, e := range entries {
+		if w.stopped() {
+			return
+		}
+		p := job.path.Join(Path(e.Name()))
+		if err := w.fn(p, e, nil); err != nil {
+			if err != filepath.SkipDir {
+				w.fail(err)
+				return
+			}
+			if !e.IsDir() {
+				return
+			}
+			continue
+		}
+		if e.IsDir() {
+			w.push(dirJob{p, e})
+		}
+	}
+}
+
+func (w *parallelWalk) push(job dirJob) {
+	w.mu.Lock()
+	w.queue = append(w.queue, job)
+	w.pending++
+	w.cond.Signal()
+	w.mu.Unlock()
+}
+
+// pop returns the next directory to read. It reports false once every
+// directory has been read or the walk was stopped.
+func (w *parallelWalk) pop() (dirJob, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(w.queue) == 0 && w.pending > 0 && w.err == nil {
+		w.cond.Wait()
+	}
+	if w.err != nil || len(w.queue) == 0 {
+		return dirJob{}, false
+	}
+	// Reading the most recently found directory first keeps the queue
+	// proportional to the depth of the tree rather than its size.
+	job := w.queue[len(w.queue)-1]
+	w.queue = w.queue[:len(w.queue)-1]
+	return job, true
+}
+
+func (w *parallelWalk) done() {
+	w.mu.Lock()
+	w.pending--
+	if w.pending == 0 {
+		w.cond.Broadcast()
+	}
+	w.mu.Unlock()
+}
+
+func (w *parallelWalk) fail(err error) {
+	w.mu.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.cond.Broadcast()
+	w.mu.Unlock()
+}
+
+func (w *parallelWalk) stopped() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err != nil
+}
+
+// orderedWalk holds the state of WalkParallelOrdered.
+type orderedWalk struct {
+	fn  WalkDirFunc
+	sem chan struct{} // one slot for every directory read ahead
+}
+
+// dirListing is the result of reading a directory ahead of time.
+type dirListing struct {
+	entries []fs.DirEntry
+	err     error
+	ready   chan struct{}
+}
+
+// readAhead starts reading the directory at path if a worker is free, and
+// returns nil otherwise. The listing must be passed to walkDir or release.
+func (w *orderedWalk) readAhead(path Path) *dirListing {
+	select {
+	case w.sem <- struct{}{}:
+	default:
+		return nil
+	}
+	l := &dirListing{ready: make(chan struct{})}
+	go func() {
+		l.entries, l.err = os.ReadDir(string(path))
+		close(l.ready)
+	}()
+	return l
+}
+
+// release waits for l to be read and frees its worker slot.
+func (w *orderedWalk) release(l *dirListing) {
+	if l != nil {
+		<-l.ready
+		<-w.sem
+	}
+}
+
+func (w *orderedWalk) walkDir(path Path, d fs.DirEntry, l *dirListing) error {
+	var entries []fs.DirEntry
+	var err error
+	if l != nil {
+		w.release(l)
+		entries, err = l.entries, l.err
+	} else {
+		entries, err = os.ReadDir(string(path))
+	}
+	if err != nil {
+		err = w.fn(path, d, err)
+		if err != nil {
+			if err == filepath.SkipDir && d.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+
+	ahead := make([]*dirListing, len(entries))
+	for i, e := range entries {
+		if e.IsDir() {
+			ahead[i] = w.readAhead(path.Join(Path(e.Name())))
+		}
+	}
+	defer func() {
+		for _, l := range ahead {
+			w.release(l)
+		}
+	}()
+
+	for i, e := range entries {
+		p := path.Join(Path(e.Name()))
+		l := ahead[i]
+		ahead[i] = nil
+		err := w.fn(p, e, nil)
+		if err == nil && e.IsDir() {
+			err = w.walkDir(p, e, l)
+			l = nil
+		}
+		w.release(l)
+		if err != nil {
+			if err == filepath.SkipDir {
+				if e.IsDir() {
+					continue
+				}
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package pathtype_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func prepareWalkTestTree(t *testing.T) path {
+	t.Helper()
+	tmpDir, err := prepareTestDirTree("dir/to/walk/skip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			p := tmpDir.Join(path(fmt.Sprintf("wide/%d/%d", i, j)))
+			if err := p.MkdirAll(0755); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range []path{"a.txt", "b.txt", "c.txt"} {
+				if err := p.Join(name).WriteFile(nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	for _, p := range []path{"dir/x.txt", "dir/to/y.txt", "dir/to/walk/skip/z.txt", "top.txt"} {
+		if err := tmpDir.Join(p).WriteFile(nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+// walkTestFunc returns a callback that records visited paths and skips
+// directories named "skip" and the rest of any directory after "b.txt".
+func walkTestFunc(mu *sync.Mutex, visited *[]string) func(p string, d fs.DirEntry, err error) error {
+	return func(p string, d fs.DirEntry, err error) error {
+		mu.Lock()
+		*visited = append(*visited, fmt.Sprint(p, err))
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if d.Name() == "skip" {
+			return filepath.SkipDir
+		}
+		if d.Name() == "b.txt" {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+}
+
+func TestWalkParallel(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	for _, root := range []path{tmpDir, tmpDir.Join("top.txt"), tmpDir.Join("missing"), tmpDir.Join("wide/0")} {
+		for _, workers := range []int{0, 1, 4} {
+			var mu sync.Mutex
+			var expect, result []string
+			fn := walkTestFunc(&mu, &expect)
+			fn1 := walkTestFunc(&mu, &result)
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(filepath.WalkDir(string(root), fn))
+			t1.Result(root.WalkParallel(workers, func(p path, d fs.DirEntry, err error) error {
+				return fn1(string(p), d, err)
+			}))
+			sort.Strings(expect)
+			sort.Strings(result)
+			t1.Expect(expect)
+			t1.Result(result)
+			t1.AssertEquals()
+		}
+	}
+}
+
+func TestWalkParallelOrdered(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	for _, root := range []path{tmpDir, tmpDir.Join("top.txt"), tmpDir.Join("missing"), tmpDir.Join("wide/0")} {
+		for _, workers := range []int{0, 1, 4} {
+			var mu sync.Mutex
+			var expect, result []string
+			fn := walkTestFunc(&mu, &expect)
+			fn1 := walkTestFunc(&mu, &result)
+			t1 := tester{TB: t, Transform: pathToString}
+			t1.Expect(filepath.WalkDir(string(root), fn))
+			t1.Result(root.WalkParallelOrdered(workers, func(p path, d fs.DirEntry, err error) error {
+				return fn1(string(p), d, err)
+			}))
+			t1.Expect(expect)
+			t1.Result(result)
+			t1.AssertEquals()
+		}
+	}
+}
+
+func TestWalkParallelError(t *testing.T) {
+	tmpDir := prepareWalkTestTree(t)
+	defer tmpDir.RemoveAll()
+
+	errStop := errors.New("stop")
+	stopAt := tmpDir.Join("wide/2")
+	for _, workers := range []int{1, 4} {
+		var mu sync.Mutex
+		var visited []path
+		err := tmpDir.WalkParallel(workers, func(p path, d fs.DirEntry, err error) error {
+			mu.Lock()
+			defer mu.Unlock()
+			visited = append(visited, p)
+			if p == stopAt {
+				return errStop
+			}
+			return nil
+		})
+		if err != errStop {
+			t.Errorf("WalkParallel(%d) returned %v, want %v", workers, err, errStop)
+		}
+		for _, p := range visited {
+			if strings.HasPrefix(string(p), string(stopAt)+string(filepath.Separator)) {
+				t.Errorf("WalkParallel(%d) visited %q below %q after stopping", workers, p, stopAt)
+			}
+		}
+
+		visited = nil
+		err = tmpDir.WalkParallelOrdered(workers, func(p path, d fs.DirEntry, err error) error {
+			visited = append(visited, p)
+			if p == stopAt {
+				return errStop
+			}
+			return nil
+		})
+		if err != errStop {
+			t.Errorf("WalkParallelOrdered(%d) returned %v, want %v", workers, err, errStop)
+		}
+		if visited[len(visited)-1] != stopAt {
+			t.Errorf("WalkParallelOrdered(%d) visited %q after stopping at %q", workers, visited[len(visited)-1], stopAt)
+		}
+	}
+}
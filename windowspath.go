@@ -0,0 +1,75 @@
+package pathtype
+
+// WindowsPath is a custom type representing a Windows path, such as
+// `C:\Users\me` or `\\host\share\dir`. Its methods are purely lexical and
+// behave as those of Path do on Windows, whatever the OS running the code,
+// so that Windows paths can be handled correctly from any platform. Both
+// '\\' and '/' are separators; results use '\\'.
+type WindowsPath string
+
+// Base returns the last element of path.
+// Trailing path separators are removed before extracting the last element.
+// If the path is empty, Base returns ".".
+// If the path consists entirely of separators, Base returns `\`.
+func (path WindowsPath) Base() WindowsPath {
+	return WindowsPath(windowsFlavor.base(string(path)))
+}
+
+// Clean returns the shortest path name equivalent to path by purely
+// lexical processing, as Path.Clean does on Windows. Slashes are replaced
+// by backslashes.
+func (path WindowsPath) Clean() WindowsPath {
+	return WindowsPath(windowsFlavor.clean(string(path)))
+}
+
+// Dir returns all but the last element of path, typically the path's directory.
+// After dropping the final element, Dir calls Clean on the path and trailing
+// separators are removed. The volume name is kept.
+func (path WindowsPath) Dir() WindowsPath {
+	return WindowsPath(windowsFlavor.dir(string(path)))
+}
+
+// IsAbs reports whether the path is absolute: a drive letter followed by a
+// separator, as in `C:\`, or a UNC or device path. `\dir` and `C:dir` are
+// not absolute.
+func (path WindowsPath) IsAbs() bool {
+	return windowsFlavor.isAbs(string(path))
+}
+
+// Join joins any number of path elements into path, adding a separator
+// between them where needed. Empty elements are ignored. The result is
+// Cleaned. However, if the argument list is empty or all its elements are
+// empty, Join returns an empty string.
+func (path WindowsPath) Join(elem ...WindowsPath) WindowsPath {
+	e1 := []string{string(path)}
+	for _, e := range elem {
+		e1 = append(e1, string(e))
+	}
+	return WindowsPath(windowsFlavor.join(e1))
+}
+
+// Rel returns a relative path that is lexically equivalent to targpath when
+// joined to path. Elements, and volume names, are compared without regard
+// to case. An error is returned if targpath can't be made relative to path,
+// as when they are on different volumes.
+func (path WindowsPath) Rel(targpath WindowsPath) (WindowsPath, error) {
+	res, err := windowsFlavor.rel(string(path), string(targpath))
+	return WindowsPath(res), err
+}
+
+// Split splits path immediately following the final separator,
+// separating it into a directory and file name component.
+// If there is no separator in path, Split returns an empty dir
+// and file set to path.
+// The returned values have the property that path = dir+file.
+func (path WindowsPath) Split() (dir, file WindowsPath) {
+	d, f := windowsFlavor.split(string(path))
+	return WindowsPath(d), WindowsPath(f)
+}
+
+// VolumeName returns the leading volume name: `C:` for `C:\foo\bar`, and
+// `\\host\share` for `\\host\share\foo`. Slashes are replaced by
+// backslashes.
+func (path WindowsPath) VolumeName() WindowsPath {
+	return WindowsPath(windowsFlavor.volumeName(string(path)))
+}
@@ -0,0 +1,132 @@
+// Package xdg locates the directories defined by the XDG Base Directory
+// Specification, https://specifications.freedesktop.org/basedir-spec/latest/,
+// as pathtype.Path values.
+//
+// Each directory is read from its environment variable, falling back to
+// the default of the specification when the variable is unset or empty.
+// As the specification requires, relative paths in the variables are
+// invalid and ignored.
+package xdg
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	pt "github.com/jonchun/pathtype"
+)
+
+// ErrNoRuntimeDir is returned by RuntimeDir when $XDG_RUNTIME_DIR is not
+// set to an absolute path. The specification leaves it to the application
+// to pick a replacement.
+var ErrNoRuntimeDir = errors.New("xdg: XDG_RUNTIME_DIR is not set")
+
+// DataHome returns the directory for user-specific data files,
+// $XDG_DATA_HOME or $HOME/.local/share.
+func DataHome() (pt.Path, error) {
+	return home("XDG_DATA_HOME", ".local/share")
+}
+
+// ConfigHome returns the directory for user-specific configuration files,
+// $XDG_CONFIG_HOME or $HOME/.config.
+func ConfigHome() (pt.Path, error) {
+	return home("XDG_CONFIG_HOME", ".config")
+}
+
+// StateHome returns the directory for user-specific state files, such as
+// logs and history, $XDG_STATE_HOME or $HOME/.local/state.
+func StateHome() (pt.Path, error) {
+	return home("XDG_STATE_HOME", ".local/state")
+}
+
+// CacheHome returns the directory for user-specific non-essential data,
+// $XDG_CACHE_HOME or $HOME/.cache.
+func CacheHome() (pt.Path, error) {
+	return home("XDG_CACHE_HOME", ".cache")
+}
+
+// RuntimeDir returns the directory for user-specific runtime files, such
+// as sockets, $XDG_RUNTIME_DIR. There is no default: if the variable is
+// not set, RuntimeDir returns ErrNoRuntimeDir.
+func RuntimeDir() (pt.Path, error) {
+	if p := getenv("XDG_RUNTIME_DIR"); p != "" {
+		return p, nil
+	}
+	return "", ErrNoRuntimeDir
+}
+
+// DataDirs returns the directories to search for data files after
+// DataHome, in order of preference, from $XDG_DATA_DIRS or
+// /usr/local/share and /usr/share.
+func DataDirs() []pt.Path {
+	return dirs("XDG_DATA_DIRS", "/usr/local/share", "/usr/share")
+}
+
+// ConfigDirs returns the directories to search for configuration files
+// after ConfigHome, in order of preference, from $XDG_CONFIG_DIRS or
+// /etc/xdg.
+func ConfigDirs() []pt.Path {
+	return dirs("XDG_CONFIG_DIRS", "/etc/xdg")
+}
+
+// FindConfig returns the first existing file or directory named rel, a
+// path relative to the configuration directories, searching ConfigHome
+// then ConfigDirs. If there is none, the error wraps fs.ErrNotExist.
+func FindConfig(rel pt.Path) (pt.Path, error) {
+	return find(rel, ConfigHome, ConfigDirs)
+}
+
+// FindData returns the first existing file or directory named rel, a path
+// relative to the data directories, searching DataHome then DataDirs. If
+// there is none, the error wraps fs.ErrNotExist.
+func FindData(rel pt.Path) (pt.Path, error) {
+	return find(rel, DataHome, DataDirs)
+}
+
+// getenv returns the value of the variable key if it is an absolute path.
+func getenv(key string) pt.Path {
+	if p := pt.Path(os.Getenv(key)); p.IsAbs() {
+		return p
+	}
+	return ""
+}
+
+func home(key string, def pt.Path) (pt.Path, error) {
+	if p := getenv(key); p != "" {
+		return p, nil
+	}
+	h, err := pt.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return h.Join(def.FromSlash()), nil
+}
+
+func dirs(key string, defs ...pt.Path) []pt.Path {
+	var res []pt.Path
+	for _, p := range pt.SplitList(os.Getenv(key)) {
+		if p.IsAbs() {
+			res = append(res, p)
+		}
+	}
+	if len(res) == 0 {
+		for _, p := range defs {
+			res = append(res, p.FromSlash())
+		}
+	}
+	return res
+}
+
+func find(rel pt.Path, home func() (pt.Path, error), dirs func() []pt.Path) (pt.Path, error) {
+	var search []pt.Path
+	if h, err := home(); err == nil {
+		search = append(search, h)
+	}
+	for _, dir := range append(search, dirs()...) {
+		p := dir.Join(rel)
+		if _, err := p.Stat(); err == nil {
+			return p, nil
+		}
+	}
+	return "", &fs.PathError{Op: "find", Path: string(rel), Err: fs.ErrNotExist}
+}
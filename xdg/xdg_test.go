@@ -0,0 +1,125 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package xdg_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+
+	pt "github.com/jonchun/pathtype"
+	"github.com/jonchun/pathtype/xdg"
+)
+
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestHomes(t *testing.T) {
+	setenv(t, "HOME", "/home/me")
+	tests := []struct {
+		key, value string
+		fn         func() (pt.Path, error)
+		expect     pt.Path
+	}{
+		{"XDG_DATA_HOME", "", xdg.DataHome, "/home/me/.local/share"},
+		{"XDG_DATA_HOME", "/data", xdg.DataHome, "/data"},
+		{"XDG_CONFIG_HOME", "", xdg.ConfigHome, "/home/me/.config"},
+		{"XDG_CONFIG_HOME", "relative", xdg.ConfigHome, "/home/me/.config"},
+		{"XDG_CONFIG_HOME", "/config", xdg.ConfigHome, "/config"},
+		{"XDG_STATE_HOME", "", xdg.StateHome, "/home/me/.local/state"},
+		{"XDG_STATE_HOME", "/state", xdg.StateHome, "/state"},
+		{"XDG_CACHE_HOME", "", xdg.CacheHome, "/home/me/.cache"},
+		{"XDG_CACHE_HOME", "/cache", xdg.CacheHome, "/cache"},
+		{"XDG_RUNTIME_DIR", "/run/user/1000", xdg.RuntimeDir, "/run/user/1000"},
+	}
+	for _, test := range tests {
+		setenv(t, test.key, test.value)
+		p, err := test.fn()
+		if p != test.expect || err != nil {
+			t.Errorf("%s=%q: got %q, %v; want %q", test.key, test.value, p, err, test.expect)
+		}
+	}
+
+	setenv(t, "XDG_RUNTIME_DIR", "")
+	if _, err := xdg.RuntimeDir(); err != xdg.ErrNoRuntimeDir {
+		t.Errorf("RuntimeDir with XDG_RUNTIME_DIR unset: got %v, want %v", err, xdg.ErrNoRuntimeDir)
+	}
+}
+
+func TestDirs(t *testing.T) {
+	tests := []struct {
+		key, value string
+		fn         func() []pt.Path
+		expect     []pt.Path
+	}{
+		{"XDG_DATA_DIRS", "", xdg.DataDirs, []pt.Path{"/usr/local/share", "/usr/share"}},
+		{"XDG_DATA_DIRS", "/a:rel:/b", xdg.DataDirs, []pt.Path{"/a", "/b"}},
+		{"XDG_DATA_DIRS", "rel", xdg.DataDirs, []pt.Path{"/usr/local/share", "/usr/share"}},
+		{"XDG_CONFIG_DIRS", "", xdg.ConfigDirs, []pt.Path{"/etc/xdg"}},
+		{"XDG_CONFIG_DIRS", "/c:/d", xdg.ConfigDirs, []pt.Path{"/c", "/d"}},
+	}
+	for _, test := range tests {
+		setenv(t, test.key, test.value)
+		if dirs := test.fn(); !reflect.DeepEqual(dirs, test.expect) {
+			t.Errorf("%s=%q: got %q, want %q", test.key, test.value, dirs, test.expect)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tmpDir, err := pt.Path("").MkdirTemp("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tmpDir.RemoveAll()
+	for _, p := range []pt.Path{"home/app/both.toml", "dir1/app/both.toml", "dir1/app/dirs.toml", "dir2/app/dirs.toml", "dir2/app/last.toml"} {
+		p = tmpDir.Join(p)
+		if err := p.Dir().MkdirAll(0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := p.WriteFile(nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	setenv(t, "XDG_CONFIG_HOME", string(tmpDir.Join("home")))
+	setenv(t, "XDG_CONFIG_DIRS", string(tmpDir.Join("dir1"))+":"+string(tmpDir.Join("dir2")))
+	setenv(t, "XDG_DATA_HOME", string(tmpDir.Join("missing")))
+	setenv(t, "XDG_DATA_DIRS", string(tmpDir.Join("dir2")))
+
+	tests := []struct {
+		fn     func(pt.Path) (pt.Path, error)
+		rel    pt.Path
+		expect pt.Path
+	}{
+		{xdg.FindConfig, "app/both.toml", "home/app/both.toml"},
+		{xdg.FindConfig, "app/dirs.toml", "dir1/app/dirs.toml"},
+		{xdg.FindConfig, "app/last.toml", "dir2/app/last.toml"},
+		{xdg.FindConfig, "app", "home/app"},
+		{xdg.FindData, "app/dirs.toml", "dir2/app/dirs.toml"},
+	}
+	for _, test := range tests {
+		p, err := test.fn(test.rel)
+		if expect := tmpDir.Join(test.expect); p != expect || err != nil {
+			t.Errorf("find %q: got %q, %v; want %q", test.rel, p, err, expect)
+		}
+	}
+
+	if _, err := xdg.FindConfig("app/none.toml"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FindConfig of a missing file: got %v, want fs.ErrNotExist", err)
+	}
+}